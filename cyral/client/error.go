@@ -1,5 +1,7 @@
 package client
 
+import "net/http"
+
 type HttpError struct {
 	err        string
 	StatusCode int
@@ -18,3 +20,9 @@ func (e *HttpError) Error() string {
 
 // *HttpError implements error
 var _ error = (*HttpError)(nil)
+
+// IsNotFound reports whether err is an HttpError with the 404 status code.
+func IsNotFound(err error) bool {
+	httpError, ok := err.(*HttpError)
+	return ok && httpError.StatusCode == http.StatusNotFound
+}
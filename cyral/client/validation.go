@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws/endpoints"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ValidateAWSRegion checks if a given aws region value is valid.
@@ -33,44 +31,3 @@ func ValidateAWSRegion(param string) error {
 	}
 	return nil
 }
-
-func ValidateRepositoryConfAnalysisRedact() schema.SchemaValidateFunc {
-	return validation.StringInSlice([]string{
-		"all",
-		"none",
-		"watched",
-	}, false)
-}
-
-func ValidateRepositoryConfAnalysisCommentAnnotationGroups() schema.SchemaValidateFunc {
-	return validation.StringInSlice([]string{
-		"identity",
-		"client",
-		"repo",
-		"sidecar",
-	}, false)
-}
-
-func ValidateRepositoryConfAnalysisLogSettings() schema.SchemaValidateFunc {
-	return validation.StringInSlice([]string{
-		"everything",
-		"dql",
-		"dml",
-		"ddl",
-		"sensitive & dql",
-		"sensitive & dml",
-		"sensitive & ddl",
-		"privileged",
-		"port-scan",
-		"auth-failure",
-		"full-table-scan",
-		"violations",
-		"connections",
-		"sensitive",
-		"data-classification",
-		"audit",
-		"error",
-		"new-connections",
-		"closed-connections",
-	}, false)
-}
@@ -0,0 +1,30 @@
+package rule
+
+const (
+	resourceName = "cyral_repository_access_rule"
+)
+
+const (
+	// Schema keys.
+	UserAccountIDKey = "user_account_id"
+	IdentityTypeKey  = "identity_type"
+	IdentityNameKey  = "identity_name"
+	ValidFromKey     = "valid_from"
+	ValidUntilKey    = "valid_until"
+	ConfigKey        = "config"
+	PolicyIDsKey     = "policy_ids"
+)
+
+const (
+	IdentityTypeUsername = "username"
+	IdentityTypeEmail    = "email"
+	IdentityTypeGroup    = "group"
+)
+
+func identityTypes() []string {
+	return []string{
+		IdentityTypeUsername,
+		IdentityTypeEmail,
+		IdentityTypeGroup,
+	}
+}
@@ -0,0 +1,149 @@
+package rule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/accessrules"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// ruleFromSchema builds the access rule described by the resource.
+func ruleFromSchema(d *schema.ResourceData) *accessrules.AccessRule {
+	rule := &accessrules.AccessRule{
+		Identity: &accessrules.AccessRulesIdentity{
+			Type: d.Get(IdentityTypeKey).(string),
+			Name: d.Get(IdentityNameKey).(string),
+		},
+	}
+	if validFrom := d.Get(ValidFromKey).(string); validFrom != "" {
+		rule.ValidFrom = &validFrom
+	}
+	if validUntil := d.Get(ValidUntilKey).(string); validUntil != "" {
+		rule.ValidUntil = &validUntil
+	}
+	if configList := d.Get(ConfigKey).([]interface{}); len(configList) > 0 && configList[0] != nil {
+		config := configList[0].(map[string]interface{})
+		rule.Config = &accessrules.AccessRulesConfig{
+			AuthorizationPolicyInstanceIDs: utils.ConvertFromInterfaceList[string](
+				config[PolicyIDsKey].([]interface{})),
+		}
+	}
+	return rule
+}
+
+func writeRuleToSchema(rule *accessrules.AccessRule, d *schema.ResourceData) error {
+	validFrom, validUntil := "", ""
+	if rule.ValidFrom != nil {
+		validFrom = *rule.ValidFrom
+	}
+	if rule.ValidUntil != nil {
+		validUntil = *rule.ValidUntil
+	}
+	var config []interface{}
+	if rule.Config != nil && len(rule.Config.AuthorizationPolicyInstanceIDs) > 0 {
+		config = []interface{}{
+			map[string]interface{}{
+				PolicyIDsKey: rule.Config.AuthorizationPolicyInstanceIDs,
+			},
+		}
+	}
+	if err := d.Set(ValidFromKey, validFrom); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, ValidFromKey, err)
+	}
+	if err := d.Set(ValidUntilKey, validUntil); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, ValidUntilKey, err)
+	}
+	if err := d.Set(ConfigKey, config); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, ConfigKey, err)
+	}
+	return nil
+}
+
+func sameIdentity(rule *accessrules.AccessRule, identityType, identityName string) bool {
+	return rule != nil && rule.Identity != nil &&
+		rule.Identity.Type == identityType && rule.Identity.Name == identityName
+}
+
+func accessRulesURL(c *client.Client, repoID, userAccountID string) string {
+	return fmt.Sprintf("https://%s/v1/repos/%s/userAccounts/%s/accessRules",
+		c.ControlPlane, repoID, userAccountID)
+}
+
+// getAccessRules retrieves the access rules of the user account. A user
+// account without access rules is returned as an empty list.
+func getAccessRules(ctx context.Context, c *client.Client, repoID, userAccountID string) ([]*accessrules.AccessRule, error) {
+	body, err := c.DoRequest(ctx, accessRulesURL(c, repoID, userAccountID), http.MethodGet, nil)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resp := accessrules.AccessRulesResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", resp))
+	return resp.AccessRules, nil
+}
+
+// putAccessRules replaces the access rules of the user account. An empty
+// list deletes all the access rules of the user account.
+func putAccessRules(ctx context.Context, c *client.Client, repoID, userAccountID string, rules []*accessrules.AccessRule) error {
+	url := accessRulesURL(c, repoID, userAccountID)
+	if len(rules) == 0 {
+		_, err := c.DoRequest(ctx, url, http.MethodDelete, nil)
+		if client.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := c.DoRequest(ctx, url, http.MethodPut, &accessrules.AccessRulesResource{AccessRules: rules})
+	return err
+}
+
+// mergeAccessRule sets the access rule of the identity in the list of
+// access rules of the user account, keeping the rules of the other
+// identities untouched. The rule replaces the existing rule of the same
+// identity, keeping its precedence, or is added with the lowest precedence.
+// A nil rule removes the rule of the identity.
+func mergeAccessRule(
+	ctx context.Context,
+	c *client.Client,
+	repoID, userAccountID, identityType, identityName string,
+	rule *accessrules.AccessRule,
+) error {
+	unlock := utils.LockKey(fmt.Sprintf("repository_access_rules/%s/%s", repoID, userAccountID))
+	defer unlock()
+
+	rules, err := getAccessRules(ctx, c, repoID, userAccountID)
+	if err != nil {
+		return err
+	}
+	merged := make([]*accessrules.AccessRule, 0, len(rules)+1)
+	found := false
+	for _, existing := range rules {
+		if !sameIdentity(existing, identityType, identityName) {
+			merged = append(merged, existing)
+			continue
+		}
+		if rule != nil && !found {
+			merged = append(merged, rule)
+		}
+		found = true
+	}
+	if !found {
+		if rule == nil {
+			return nil
+		}
+		merged = append(merged, rule)
+	}
+	return putAccessRules(ctx, c, repoID, userAccountID, merged)
+}
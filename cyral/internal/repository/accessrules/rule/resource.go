@@ -0,0 +1,234 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func resourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the access rule of a single identity for a " +
+			"[`cyral_repository_user_account`](./repository_user_account.md), without changing the " +
+			"access rules of the other identities." +
+			"\n\nNew access rules are added with the lowest precedence among the access rules of the " +
+			"user account. This resource must not be combined with the " +
+			"[`cyral_repository_access_rules`](./repository_access_rules.md) resource for the same " +
+			"user account, as the latter manages all its access rules.",
+		CreateContext: resourceRepositoryAccessRuleCreate,
+		ReadContext:   resourceRepositoryAccessRuleRead,
+		UpdateContext: resourceRepositoryAccessRuleUpdate,
+		DeleteContext: resourceRepositoryAccessRuleDelete,
+		CustomizeDiff: resourceRepositoryAccessRuleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			utils.IDKey: {
+				Description: "ID of this resource in Terraform state, in the format " +
+					"`{repository_id}/{user_account_id}/{identity_type}/{identity_name}`.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			utils.RepositoryIDKey: {
+				Description: "ID of the repository.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			UserAccountIDKey: {
+				Description: "ID of the database account. This should be the attribute `user_account_id` " +
+					"of the resource `cyral_repository_user_account`.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			IdentityTypeKey: {
+				Description: "Type of the identity getting access. List of supported values:" +
+					utils.SupportedValuesAsMarkdown(identityTypes()),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(identityTypes(), false),
+			},
+			IdentityNameKey: {
+				Description: "The name of the person/group getting access.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			ValidFromKey: {
+				Description: "The start time for the grant, in RFC 3339 format. Eg. `2022-01-24T18:30:00Z`. " +
+					"Must be before `" + ValidUntilKey + "`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			ValidUntilKey: {
+				Description: "The end time for the grant, in RFC 3339 format. Eg. `2022-01-24T18:30:00Z`. " +
+					"Must be after `" + ValidFromKey + "`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			ConfigKey: {
+				Description: "Extra (optional) configuration parameters.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						PolicyIDsKey: {
+							Description: "Extra authorization policies, such as PagerDuty or DUO." +
+								" Use the attribute `id` from resources `cyral_integration_pager_duty`" +
+								" and `cyral_integration_mfa_duo`.",
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(
+				ctx context.Context,
+				d *schema.ResourceData,
+				m interface{},
+			) ([]*schema.ResourceData, error) {
+				ids, err := utils.UnMarshalComposedID(d.Id(), "/", 4)
+				if err != nil {
+					return nil, fmt.Errorf("failed to unmarshal ID: %v", err)
+				}
+				d.Set(utils.RepositoryIDKey, ids[0])
+				d.Set(UserAccountIDKey, ids[1])
+				d.Set(IdentityTypeKey, ids[2])
+				// Identity names, such as group names, may contain the separator.
+				d.Set(IdentityNameKey, strings.Join(ids[3:], "/"))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+func resourceRepositoryAccessRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryAccessRuleCreate")
+	c := m.(*client.Client)
+	repoID, userAccountID, identityType, identityName := ruleKey(d)
+
+	if err := mergeAccessRule(ctx, c, repoID, userAccountID, identityType, identityName, ruleFromSchema(d)); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to create %s", resourceName), err.Error())
+	}
+	d.SetId(utils.MarshalComposedID([]string{repoID, userAccountID, identityType, identityName}, "/"))
+
+	tflog.Debug(ctx, "End resourceRepositoryAccessRuleCreate")
+	return resourceRepositoryAccessRuleRead(ctx, d, m)
+}
+
+func resourceRepositoryAccessRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryAccessRuleRead")
+	c := m.(*client.Client)
+	repoID, userAccountID, identityType, identityName := ruleKey(d)
+
+	rules, err := getAccessRules(ctx, c, repoID, userAccountID)
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+	}
+	for _, rule := range rules {
+		if sameIdentity(rule, identityType, identityName) {
+			if err := writeRuleToSchema(rule, d); err != nil {
+				return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+			}
+			tflog.Debug(ctx, "End resourceRepositoryAccessRuleRead")
+			return nil
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Access rule for %s %q not found, removing %s from state.",
+		identityType, identityName, resourceName))
+	d.SetId("")
+	return nil
+}
+
+func resourceRepositoryAccessRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryAccessRuleUpdate")
+	c := m.(*client.Client)
+	repoID, userAccountID, identityType, identityName := ruleKey(d)
+
+	if err := mergeAccessRule(ctx, c, repoID, userAccountID, identityType, identityName, ruleFromSchema(d)); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName), err.Error())
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryAccessRuleUpdate")
+	return resourceRepositoryAccessRuleRead(ctx, d, m)
+}
+
+func resourceRepositoryAccessRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryAccessRuleDelete")
+	c := m.(*client.Client)
+	repoID, userAccountID, identityType, identityName := ruleKey(d)
+
+	if err := mergeAccessRule(ctx, c, repoID, userAccountID, identityType, identityName, nil); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to delete %s", resourceName), err.Error())
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryAccessRuleDelete")
+	return nil
+}
+
+func ruleKey(d *schema.ResourceData) (repoID, userAccountID, identityType, identityName string) {
+	return d.Get(utils.RepositoryIDKey).(string),
+		d.Get(UserAccountIDKey).(string),
+		d.Get(IdentityTypeKey).(string),
+		d.Get(IdentityNameKey).(string)
+}
+
+// resourceRepositoryAccessRuleCustomizeDiff checks that the grant starts
+// before it ends.
+func resourceRepositoryAccessRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(ValidFromKey) || !d.NewValueKnown(ValidUntilKey) {
+		return nil
+	}
+	validFrom, validUntil := d.Get(ValidFromKey).(string), d.Get(ValidUntilKey).(string)
+	if validFrom == "" || validUntil == "" {
+		return nil
+	}
+	from, err := time.Parse(time.RFC3339, validFrom)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", ValidFromKey, err)
+	}
+	until, err := time.Parse(time.RFC3339, validUntil)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", ValidUntilKey, err)
+	}
+	if !from.Before(until) {
+		return fmt.Errorf("'%s' (%s) must be before '%s' (%s)",
+			ValidFromKey, validFrom, ValidUntilKey, validUntil)
+	}
+	return nil
+}
+
+// suppressEquivalentTimes ignores differences in the representation of the
+// same instant, such as the time zone offset or fractional seconds.
+func suppressEquivalentTimes(_, old, new string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
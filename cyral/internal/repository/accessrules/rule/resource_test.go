@@ -0,0 +1,94 @@
+package rule_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	repositoryAccessRuleResourceName = "repository-access-rule"
+)
+
+func TestAccRepositoryAccessRuleResource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryAccessRuleConfig("2022-01-02T10:20:30Z", "3022-01-02T10:20:30Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.email", "valid_from", "2022-01-02T10:20:30Z"),
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.email", "config.0.policy_ids.#", "1"),
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.group", "identity_name", "identityGroup"),
+				),
+			},
+			{
+				// Only the email rule changes, the group rule must be kept.
+				Config: repositoryAccessRuleConfig("2023-11-12T10:20:30Z", "3023-11-12T10:20:30Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.email", "valid_from", "2023-11-12T10:20:30Z"),
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.email", "valid_until", "3023-11-12T10:20:30Z"),
+					resource.TestCheckResourceAttr("cyral_repository_access_rule.group", "identity_type", "group"),
+				),
+			},
+			{
+				Config:      repositoryAccessRuleConfig("3023-11-12T10:20:30Z", "2023-11-12T10:20:30Z"),
+				ExpectError: regexp.MustCompile(`must be before 'valid_until'`),
+			},
+			{
+				Config:      repositoryAccessRuleConfig("2023-11-12 10:20:30", "3023-11-12T10:20:30Z"),
+				ExpectError: regexp.MustCompile(`expected "valid_from" to be a valid RFC3339 date`),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      "cyral_repository_access_rule.group",
+			},
+		},
+	})
+}
+
+func repositoryAccessRuleConfig(validFrom, validUntil string) string {
+	config := utils.FormatBasicRepositoryIntoConfig(
+		utils.BasicRepositoryResName,
+		utils.AccTestName(repositoryAccessRuleResourceName, "repository"),
+		"mongodb",
+		"mongo.local",
+		3333,
+	)
+	config += fmt.Sprintf(`
+	resource "cyral_repository_user_account" "test" {
+		repository_id = %s
+		name          = "%s"
+		auth_scheme {
+			environment_variable {
+				variable_name = "FOOBAR"
+			}
+		}
+	}
+
+	resource "cyral_repository_access_rule" "email" {
+		repository_id   = %s
+		user_account_id = cyral_repository_user_account.test.user_account_id
+		identity_type   = "email"
+		identity_name   = "identityEmail"
+		valid_from      = "%s"
+		valid_until     = "%s"
+		config {
+			policy_ids = ["policy1"]
+		}
+	}
+
+	resource "cyral_repository_access_rule" "group" {
+		repository_id   = %s
+		user_account_id = cyral_repository_user_account.test.user_account_id
+		identity_type   = "group"
+		identity_name   = "identityGroup"
+	}`, utils.BasicRepositoryID, utils.AccTestName(repositoryAccessRuleResourceName, "user-account"),
+		utils.BasicRepositoryID, validFrom, validUntil, utils.BasicRepositoryID)
+	return config
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/resourcetype"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -31,12 +32,16 @@ var resourceContextHandler = core.HTTPContextHandler{
 	},
 }
 
+func sidecarIDFromBinding(d *schema.ResourceData) string {
+	return d.Get(utils.SidecarIDKey).(string)
+}
+
 func resourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manages [cyral repository to sidecar bindings](https://cyral.com/docs/sidecars/manage/bind-repo/).",
-		CreateContext: resourceContextHandler.CreateContext(),
+		CreateContext: health.WithWaitForHealthy(sidecarIDFromBinding, resourceContextHandler.CreateContext()),
 		ReadContext:   resourceContextHandler.ReadContext(),
-		UpdateContext: resourceContextHandler.UpdateContext(),
+		UpdateContext: health.WithWaitForHealthy(sidecarIDFromBinding, resourceContextHandler.UpdateContext()),
 		DeleteContext: resourceContextHandler.DeleteContext(),
 		SchemaVersion: 2,
 		Schema: map[string]*schema.Schema{
@@ -83,6 +88,11 @@ func resourceSchema() *schema.Resource {
 					},
 				},
 			},
+			health.WaitForHealthyKey: health.WaitForHealthySchema("create and update"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(health.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(health.DefaultWaitTimeout),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(
@@ -0,0 +1,18 @@
+package bulk
+
+const (
+	resourceName = "cyral_repositories"
+)
+
+const (
+	// Schema keys.
+	RepositoryKey    = "repository"
+	RepositoryIDsKey = "repository_ids"
+	KeyKey           = "key"
+	ParallelismKey   = "parallelism"
+)
+
+const (
+	defaultParallelism = 10
+	maxParallelism     = 50
+)
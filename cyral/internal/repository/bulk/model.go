@@ -0,0 +1,177 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+)
+
+// RepoSpecs maps the user-defined key of each repository block to the
+// repository it describes.
+type RepoSpecs map[string]*repository.RepoInfo
+
+// repoSpecsFromInterface parses the repository blocks. Blocks that fail
+// validation are returned in the error map instead of aborting the whole
+// set, so that the valid ones can still be reconciled.
+func repoSpecsFromInterface(i []interface{}) (RepoSpecs, map[string]error, error) {
+	specs := make(RepoSpecs)
+	specErrors := make(map[string]error)
+	for _, specIface := range i {
+		specMap := specIface.(map[string]interface{})
+		key := specMap[KeyKey].(string)
+		if _, ok := specs[key]; ok {
+			return nil, nil, fmt.Errorf("duplicate '%s' found in '%s' blocks: %q", KeyKey, RepositoryKey, key)
+		}
+		if _, ok := specErrors[key]; ok {
+			return nil, nil, fmt.Errorf("duplicate '%s' found in '%s' blocks: %q", KeyKey, RepositoryKey, key)
+		}
+		repo := &repository.RepoInfo{}
+		if err := repo.ReadFromMap(specMap); err != nil {
+			specErrors[key] = err
+			continue
+		}
+		specs[key] = repo
+	}
+	return specs, specErrors, nil
+}
+
+// AsInterface returns the repository blocks, as stored in the Terraform
+// state.
+func (specs RepoSpecs) AsInterface() []interface{} {
+	repos := make([]interface{}, 0, len(specs))
+	for key, repo := range specs {
+		repoMap := repo.AsMap()
+		repoMap[KeyKey] = key
+		repos = append(repos, repoMap)
+	}
+	return repos
+}
+
+func repoIDsFromSchema(d *schema.ResourceData) map[string]string {
+	ids := make(map[string]string)
+	for key, id := range d.Get(RepositoryIDsKey).(map[string]interface{}) {
+		ids[key] = id.(string)
+	}
+	return ids
+}
+
+func createRepository(ctx context.Context, c *client.Client, repo *repository.RepoInfo) (string, error) {
+	url := fmt.Sprintf("https://%s/v1/repos", c.ControlPlane)
+	body, err := c.DoRequest(ctx, url, http.MethodPost, repo)
+	if err != nil {
+		return "", err
+	}
+	resp := core.IDBasedResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func updateRepository(ctx context.Context, c *client.Client, id string, repo *repository.RepoInfo) error {
+	repo.ID = id
+	url := fmt.Sprintf("https://%s/v1/repos/%s", c.ControlPlane, id)
+	_, err := c.DoRequest(ctx, url, http.MethodPut, repo)
+	return err
+}
+
+func deleteRepository(ctx context.Context, c *client.Client, id string) error {
+	url := fmt.Sprintf("https://%s/v1/repos/%s", c.ControlPlane, id)
+	_, err := c.DoRequest(ctx, url, http.MethodDelete, nil)
+	if client.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// reconciliationPlan holds the keys of the repository blocks to create,
+// replace, update and delete, each sorted alphabetically.
+type reconciliationPlan struct {
+	Creates  []string
+	Replaces []string
+	Updates  []string
+	Deletes  []string
+}
+
+// planReconciliation compares the repositories in the state, given by their
+// IDs and their old specs, with the new specs from the configuration.
+// Repositories whose block failed validation are left untouched. Changing the
+// type or the name of a repository requires replacing it.
+func planReconciliation(
+	ids map[string]string,
+	oldSpecs RepoSpecs,
+	newSpecs RepoSpecs,
+	specErrors map[string]error,
+) reconciliationPlan {
+	plan := reconciliationPlan{}
+	for key := range ids {
+		if _, ok := newSpecs[key]; ok {
+			continue
+		}
+		if _, ok := specErrors[key]; ok {
+			continue
+		}
+		plan.Deletes = append(plan.Deletes, key)
+	}
+	for key, spec := range newSpecs {
+		oldSpec := oldSpecs[key]
+		switch _, exists := ids[key]; {
+		case !exists:
+			plan.Creates = append(plan.Creates, key)
+		case oldSpec == nil || oldSpec.Type != spec.Type || oldSpec.Name != spec.Name:
+			plan.Replaces = append(plan.Replaces, key)
+		case !reflect.DeepEqual(oldSpec, spec):
+			plan.Updates = append(plan.Updates, key)
+		}
+	}
+	sort.Strings(plan.Creates)
+	sort.Strings(plan.Replaces)
+	sort.Strings(plan.Updates)
+	sort.Strings(plan.Deletes)
+	return plan
+}
+
+// repoOperation is a single create, update or delete operation on the
+// repository identified by key.
+type repoOperation struct {
+	key  string
+	name string
+	run  func(ctx context.Context) error
+}
+
+// runOperations runs the given operations using at most parallelism
+// concurrent workers and returns the errors of the ones that failed,
+// keyed by repository key.
+func runOperations(ctx context.Context, parallelism int, ops []repoOperation) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+	sem := make(chan struct{}, parallelism)
+	for _, op := range ops {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(op repoOperation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			tflog.Debug(ctx, fmt.Sprintf("Running %s operation on repository %q", op.name, op.key))
+			if err := op.run(ctx); err != nil {
+				mu.Lock()
+				errs[op.key] = fmt.Errorf("unable to %s repository: %w", op.name, err)
+				mu.Unlock()
+			}
+		}(op)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+)
+
+func testRepoSpec(typ, name, host string) *repository.RepoInfo {
+	return &repository.RepoInfo{
+		Type: typ,
+		Name: name,
+		Host: host,
+		Port: 5432,
+	}
+}
+
+func TestPlanReconciliation(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		ids        map[string]string
+		oldSpecs   RepoSpecs
+		newSpecs   RepoSpecs
+		specErrors map[string]error
+		expected   reconciliationPlan
+	}{
+		{
+			desc: "new resource creates all repositories",
+			ids:  map[string]string{},
+			newSpecs: RepoSpecs{
+				"b": testRepoSpec("postgresql", "b", "b.local"),
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			expected: reconciliationPlan{Creates: []string{"a", "b"}},
+		},
+		{
+			desc: "unchanged repositories are left untouched",
+			ids:  map[string]string{"a": "id-a"},
+			oldSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			newSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			expected: reconciliationPlan{},
+		},
+		{
+			desc: "changed host updates in place",
+			ids:  map[string]string{"a": "id-a"},
+			oldSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			newSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a-new.local"),
+			},
+			expected: reconciliationPlan{Updates: []string{"a"}},
+		},
+		{
+			desc: "changed type or name replaces",
+			ids:  map[string]string{"a": "id-a", "b": "id-b"},
+			oldSpecs: RepoSpecs{
+				"a": testRepoSpec("mysql", "a", "a.local"),
+				"b": testRepoSpec("postgresql", "b", "b.local"),
+			},
+			newSpecs: RepoSpecs{
+				"a": testRepoSpec("mariadb", "a", "a.local"),
+				"b": testRepoSpec("postgresql", "b-renamed", "b.local"),
+			},
+			expected: reconciliationPlan{Replaces: []string{"a", "b"}},
+		},
+		{
+			desc: "repository in the state without old spec is replaced",
+			ids:  map[string]string{"a": "id-a"},
+			newSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			expected: reconciliationPlan{Replaces: []string{"a"}},
+		},
+		{
+			desc: "removed blocks are deleted",
+			ids:  map[string]string{"a": "id-a", "b": "id-b"},
+			oldSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+				"b": testRepoSpec("postgresql", "b", "b.local"),
+			},
+			newSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			expected: reconciliationPlan{Deletes: []string{"b"}},
+		},
+		{
+			desc: "invalid blocks are not deleted",
+			ids:  map[string]string{"a": "id-a"},
+			oldSpecs: RepoSpecs{
+				"a": testRepoSpec("postgresql", "a", "a.local"),
+			},
+			newSpecs:   RepoSpecs{},
+			specErrors: map[string]error{"a": errors.New("invalid")},
+			expected:   reconciliationPlan{},
+		},
+		{
+			desc: "mixed operations",
+			ids:  map[string]string{"keep": "id-1", "update": "id-2", "replace": "id-3", "delete": "id-4"},
+			oldSpecs: RepoSpecs{
+				"keep":    testRepoSpec("postgresql", "keep", "keep.local"),
+				"update":  testRepoSpec("postgresql", "update", "update.local"),
+				"replace": testRepoSpec("mysql", "replace", "replace.local"),
+				"delete":  testRepoSpec("postgresql", "delete", "delete.local"),
+			},
+			newSpecs: RepoSpecs{
+				"keep":    testRepoSpec("postgresql", "keep", "keep.local"),
+				"update":  testRepoSpec("postgresql", "update", "update-new.local"),
+				"replace": testRepoSpec("mariadb", "replace", "replace.local"),
+				"create":  testRepoSpec("postgresql", "create", "create.local"),
+			},
+			expected: reconciliationPlan{
+				Creates:  []string{"create"},
+				Replaces: []string{"replace"},
+				Updates:  []string{"update"},
+				Deletes:  []string{"delete"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			plan := planReconciliation(testCase.ids, testCase.oldSpecs, testCase.newSpecs, testCase.specErrors)
+			assert.Equal(t, testCase.expected, plan)
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func resourceSchema() *schema.Resource {
+	repoSchema := repository.RepoInfoSchema()
+	repoSchema[KeyKey] = &schema.Schema{
+		Description: "Unique key that identifies this repository in the set, usually the key of the " +
+			"inventory map the blocks are generated from. Changing the key of an existing block " +
+			"deletes and recreates the corresponding repository.",
+		Type:     schema.TypeString,
+		Required: true,
+	}
+
+	return &schema.Resource{
+		Description: "Manages a set of [repositories](https://cyral.com/docs/how-to/track-repos/) " +
+			"in a single resource. Repositories are created, updated and deleted concurrently, and " +
+			"a failure on one repository is reported as a warning without failing the others." +
+			"\n\nRepositories that fail to be created or updated are retried in the next apply. " +
+			"Changing the `" + repository.RepoTypeKey + "` or the `" + repository.RepoNameKey +
+			"` of a repository deletes and recreates it.",
+		CreateContext: resourceRepositoriesCreate,
+		ReadContext:   resourceRepositoriesRead,
+		UpdateContext: resourceRepositoriesUpdate,
+		DeleteContext: resourceRepositoriesDelete,
+		Schema: map[string]*schema.Schema{
+			utils.IDKey: {
+				Description: "ID of this resource (locally computed to be used in Terraform state).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			ParallelismKey: {
+				Description: fmt.Sprintf("Maximum number of concurrent requests sent to the control "+
+					"plane. Defaults to `%d`, and must be at most `%d`.", defaultParallelism, maxParallelism),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultParallelism,
+				ValidateFunc: validation.IntBetween(1, maxParallelism),
+			},
+			RepositoryKey: {
+				Description: "Repositories managed by this resource. Each block accepts the same " +
+					"arguments as the [`cyral_repository`](./repository.md) resource, plus a unique `" +
+					KeyKey + "`.",
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: repoSchema,
+				},
+			},
+			RepositoryIDsKey: {
+				Description: "IDs of the repositories in the Cyral environment, keyed by the `" + KeyKey +
+					"` of the corresponding `" + RepositoryKey + "` block.",
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceRepositoriesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoriesCreate")
+	d.SetId(uuid.New().String())
+	diags := reconcileRepositories(ctx, d, m.(*client.Client), nil)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "End resourceRepositoriesCreate")
+	return append(diags, resourceRepositoriesRead(ctx, d, m)...)
+}
+
+func resourceRepositoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoriesRead")
+	c := m.(*client.Client)
+
+	// All the pages are fetched, as the managed repositories may be anywhere
+	// in the list.
+	repos, err := repository.ListRepositories(ctx, c, "", "")
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+	}
+	reposByID := make(map[string]*repository.RepoInfo, len(repos))
+	for i := range repos {
+		reposByID[repos[i].ID] = &repos[i].Repo
+	}
+
+	// Only the repositories that still exist in the control plane are kept in
+	// the state. The missing ones, including the ones that failed to be
+	// created, will be planned for creation in the next apply.
+	ids := repoIDsFromSchema(d)
+	specs := make(RepoSpecs)
+	for key, id := range ids {
+		repo, ok := reposByID[id]
+		if !ok {
+			tflog.Debug(ctx, fmt.Sprintf("Repository %q (%s) not found, removing it from state.", key, id))
+			delete(ids, key)
+			continue
+		}
+		specs[key] = repo
+	}
+
+	if err := d.Set(RepositoryKey, specs.AsInterface()); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName),
+			fmt.Errorf(utils.ErrorSettingFieldFmt, RepositoryKey, err).Error())
+	}
+	if err := d.Set(RepositoryIDsKey, ids); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName),
+			fmt.Errorf(utils.ErrorSettingFieldFmt, RepositoryIDsKey, err).Error())
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoriesRead")
+	return nil
+}
+
+func resourceRepositoriesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoriesUpdate")
+	oldReposIface, _ := d.GetChange(RepositoryKey)
+	// The old blocks come from the state, which was written from the
+	// control plane data, so they are not expected to fail validation.
+	oldSpecs, _, err := repoSpecsFromInterface(oldReposIface.(*schema.Set).List())
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName), err.Error())
+	}
+	diags := reconcileRepositories(ctx, d, m.(*client.Client), oldSpecs)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "End resourceRepositoriesUpdate")
+	return append(diags, resourceRepositoriesRead(ctx, d, m)...)
+}
+
+func resourceRepositoriesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoriesDelete")
+	c := m.(*client.Client)
+
+	ids := repoIDsFromSchema(d)
+	var mu sync.Mutex
+	var ops []repoOperation
+	for key, id := range ids {
+		key, id := key, id
+		ops = append(ops, repoOperation{
+			key:  key,
+			name: "delete",
+			run: func(ctx context.Context) error {
+				if err := deleteRepository(ctx, c, id); err != nil {
+					return err
+				}
+				mu.Lock()
+				delete(ids, key)
+				mu.Unlock()
+				return nil
+			},
+		})
+	}
+	errs := runOperations(ctx, d.Get(ParallelismKey).(int), ops)
+
+	if len(errs) > 0 {
+		// Keep the repositories that could not be deleted in the state, so
+		// that the deletion can be retried.
+		d.Set(RepositoryIDsKey, ids)
+		return operationDiagnostics(errs, diag.Error)
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoriesDelete")
+	return nil
+}
+
+// reconcileRepositories creates, updates and deletes repositories so that
+// the control plane matches the repository blocks in the configuration.
+// oldSpecs holds the repository blocks currently in the state. Failures on
+// individual repositories are returned as warnings.
+func reconcileRepositories(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.Client,
+	oldSpecs RepoSpecs,
+) diag.Diagnostics {
+	newSpecs, specErrors, err := repoSpecsFromInterface(d.Get(RepositoryKey).(*schema.Set).List())
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to reconcile %s", resourceName), err.Error())
+	}
+
+	ids := repoIDsFromSchema(d)
+	var mu sync.Mutex
+	setID := func(key, id string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if id == "" {
+			delete(ids, key)
+		} else {
+			ids[key] = id
+		}
+	}
+
+	plan := planReconciliation(ids, oldSpecs, newSpecs, specErrors)
+	var ops []repoOperation
+	for _, key := range plan.Deletes {
+		id := ids[key]
+		key := key
+		ops = append(ops, repoOperation{
+			key:  key,
+			name: "delete",
+			run: func(ctx context.Context) error {
+				if err := deleteRepository(ctx, c, id); err != nil {
+					return err
+				}
+				setID(key, "")
+				return nil
+			},
+		})
+	}
+	for _, key := range plan.Creates {
+		key, spec := key, newSpecs[key]
+		ops = append(ops, repoOperation{
+			key:  key,
+			name: "create",
+			run: func(ctx context.Context) error {
+				newID, err := createRepository(ctx, c, spec)
+				if err != nil {
+					return err
+				}
+				setID(key, newID)
+				return nil
+			},
+		})
+	}
+	for _, key := range plan.Replaces {
+		key, id, spec := key, ids[key], newSpecs[key]
+		ops = append(ops, repoOperation{
+			key:  key,
+			name: "replace",
+			run: func(ctx context.Context) error {
+				if err := deleteRepository(ctx, c, id); err != nil {
+					return err
+				}
+				setID(key, "")
+				newID, err := createRepository(ctx, c, spec)
+				if err != nil {
+					return err
+				}
+				setID(key, newID)
+				return nil
+			},
+		})
+	}
+	for _, key := range plan.Updates {
+		key, id, spec := key, ids[key], newSpecs[key]
+		ops = append(ops, repoOperation{
+			key:  key,
+			name: "update",
+			run: func(ctx context.Context) error {
+				return updateRepository(ctx, c, id, spec)
+			},
+		})
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Reconciling %d repositories", len(ops)))
+
+	errs := runOperations(ctx, d.Get(ParallelismKey).(int), ops)
+	for key, err := range specErrors {
+		errs[key] = fmt.Errorf("invalid repository: %w", err)
+	}
+
+	if err := d.Set(RepositoryIDsKey, ids); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to reconcile %s", resourceName),
+			fmt.Errorf(utils.ErrorSettingFieldFmt, RepositoryIDsKey, err).Error())
+	}
+
+	return operationDiagnostics(errs, diag.Warning)
+}
+
+// operationDiagnostics converts the per-repository errors into diagnostics
+// of the given severity, sorted by repository key.
+func operationDiagnostics(errs map[string]error, severity diag.Severity) diag.Diagnostics {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("Unable to reconcile repository %q of %s", key, resourceName),
+			Detail:   errs[key].Error(),
+		})
+	}
+	return diags
+}
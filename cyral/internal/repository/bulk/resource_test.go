@@ -0,0 +1,98 @@
+package bulk_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	repositoriesResourceName = "repositories"
+)
+
+type testRepo struct {
+	key    string
+	typ    string
+	host   string
+	port   int
+	labels []string
+}
+
+func TestAccRepositoriesResource(t *testing.T) {
+	initial := []testRepo{
+		{key: "pg-1", typ: "postgresql", host: "pg1.local", port: 5432, labels: []string{"team-a"}},
+		{key: "pg-2", typ: "postgresql", host: "pg2.local", port: 5432, labels: []string{"team-b"}},
+		{key: "mysql-1", typ: "mysql", host: "mysql1.local", port: 3306},
+	}
+	// Updates pg-1, removes pg-2, replaces mysql-1 and adds mysql-2.
+	updated := []testRepo{
+		{key: "pg-1", typ: "postgresql", host: "pg1-new.local", port: 5433, labels: []string{"team-a", "prod"}},
+		{key: "mysql-1", typ: "mariadb", host: "mysql1.local", port: 3306},
+		{key: "mysql-2", typ: "mysql", host: "mysql2.local", port: 3306},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: repositoriesConfig(initial),
+				Check:  repositoriesChecks(initial),
+			},
+			{
+				Config: repositoriesConfig(updated),
+				Check:  repositoriesChecks(updated),
+			},
+		},
+	})
+}
+
+func repositoriesConfig(repos []testRepo) string {
+	var blocks []string
+	for _, repo := range repos {
+		blocks = append(blocks, fmt.Sprintf(`
+		repository {
+			key    = "%s"
+			type   = "%s"
+			name   = "%s"
+			labels = %s
+			repo_node {
+				host = "%s"
+				port = %d
+			}
+		}`, repo.key, repo.typ, utils.AccTestName(repositoriesResourceName, repo.key),
+			utils.ListToStr(repo.labels), repo.host, repo.port))
+	}
+	return fmt.Sprintf(`
+	resource "cyral_repositories" "test" {
+		parallelism = 2
+		%s
+	}`, strings.Join(blocks, "\n"))
+}
+
+func repositoriesChecks(repos []testRepo) resource.TestCheckFunc {
+	resourceFullName := "cyral_repositories.test"
+	checkFuncs := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(resourceFullName, "repository.#", fmt.Sprintf("%d", len(repos))),
+		resource.TestCheckResourceAttr(resourceFullName, "repository_ids.%", fmt.Sprintf("%d", len(repos))),
+	}
+	for _, repo := range repos {
+		checkFuncs = append(checkFuncs,
+			resource.TestMatchResourceAttr(resourceFullName,
+				fmt.Sprintf("repository_ids.%s", repo.key), utils.NotZeroRegex()),
+			resource.TestCheckTypeSetElemNestedAttrs(resourceFullName, "repository.*",
+				map[string]string{
+					"key":              repo.key,
+					"type":             repo.typ,
+					"name":             utils.AccTestName(repositoriesResourceName, repo.key),
+					"labels.#":         fmt.Sprintf("%d", len(repo.labels)),
+					"repo_node.0.host": repo.host,
+					"repo_node.0.port": fmt.Sprintf("%d", repo.port),
+				}),
+		)
+	}
+	return resource.ComposeTestCheckFunc(checkFuncs...)
+}
@@ -1,5 +1,147 @@
 package confanalysis
 
+import "github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+
 const (
 	resourceName = "cyral_repository_conf_analysis"
 )
+
+const (
+	// Schema keys
+	RedactKey                     = "redact"
+	AlertOnViolationKey           = "alert_on_violation"
+	DisablePreConfiguredAlertsKey = "disable_pre_configured_alerts"
+	EnableDataMaskingKey          = "enable_data_masking"
+	MaskAllOccurrencesKey         = "mask_all_occurrences"
+	BlockOnViolationKey           = "block_on_violation"
+	DisableFilterAnalysisKey      = "disable_filter_analysis"
+	EnableDatasetRewritesKey      = "enable_dataset_rewrites"
+	CommentAnnotationGroupsKey    = "comment_annotation_groups"
+	LogGroupsKey                  = "log_groups"
+	EffectiveConfigKey            = "effective_config"
+)
+
+type Redact string
+
+const (
+	RedactAll     = Redact("all")
+	RedactNone    = Redact("none")
+	RedactWatched = Redact("watched")
+)
+
+func RedactTypes() []Redact {
+	return []Redact{
+		RedactAll,
+		RedactNone,
+		RedactWatched,
+	}
+}
+
+func RedactTypesAsString() []string {
+	return utils.ToSliceOfString[Redact](RedactTypes(), func(r Redact) string {
+		return string(r)
+	})
+}
+
+type CommentAnnotationGroup string
+
+const (
+	CommentAnnotationIdentity = CommentAnnotationGroup("identity")
+	CommentAnnotationClient   = CommentAnnotationGroup("client")
+	CommentAnnotationRepo     = CommentAnnotationGroup("repo")
+	CommentAnnotationSidecar  = CommentAnnotationGroup("sidecar")
+)
+
+func CommentAnnotationGroups() []CommentAnnotationGroup {
+	return []CommentAnnotationGroup{
+		CommentAnnotationIdentity,
+		CommentAnnotationClient,
+		CommentAnnotationRepo,
+		CommentAnnotationSidecar,
+	}
+}
+
+func CommentAnnotationGroupsAsString() []string {
+	return utils.ToSliceOfString[CommentAnnotationGroup](CommentAnnotationGroups(), func(g CommentAnnotationGroup) string {
+		return string(g)
+	})
+}
+
+type LogGroup string
+
+const (
+	LogEverything         = LogGroup("everything")
+	LogDQL                = LogGroup("dql")
+	LogDML                = LogGroup("dml")
+	LogDDL                = LogGroup("ddl")
+	LogSensitiveDQL       = LogGroup("sensitive & dql")
+	LogSensitiveDML       = LogGroup("sensitive & dml")
+	LogSensitiveDDL       = LogGroup("sensitive & ddl")
+	LogPrivileged         = LogGroup("privileged")
+	LogPortScan           = LogGroup("port-scan")
+	LogAuthFailure        = LogGroup("auth-failure")
+	LogFullTableScan      = LogGroup("full-table-scan")
+	LogViolations         = LogGroup("violations")
+	LogConnections        = LogGroup("connections")
+	LogSensitive          = LogGroup("sensitive")
+	LogDataClassification = LogGroup("data-classification")
+	LogAudit              = LogGroup("audit")
+	LogError              = LogGroup("error")
+	LogNewConnections     = LogGroup("new-connections")
+	LogClosedConnections  = LogGroup("closed-connections")
+)
+
+func LogGroups() []LogGroup {
+	return []LogGroup{
+		LogEverything,
+		LogDQL,
+		LogDML,
+		LogDDL,
+		LogSensitiveDQL,
+		LogSensitiveDML,
+		LogSensitiveDDL,
+		LogPrivileged,
+		LogPortScan,
+		LogAuthFailure,
+		LogFullTableScan,
+		LogViolations,
+		LogConnections,
+		LogSensitive,
+		LogDataClassification,
+		LogAudit,
+		LogError,
+		LogNewConnections,
+		LogClosedConnections,
+	}
+}
+
+func LogGroupsAsString() []string {
+	return utils.ToSliceOfString[LogGroup](LogGroups(), func(g LogGroup) string {
+		return string(g)
+	})
+}
+
+// logGroupAliases maps the log groups that enable other log groups to the
+// log groups they enable.
+func logGroupAliases() map[LogGroup][]LogGroup {
+	var everything []LogGroup
+	for _, logGroup := range LogGroups() {
+		if logGroup != LogEverything && logGroup != LogAudit {
+			everything = append(everything, logGroup)
+		}
+	}
+	return map[LogGroup][]LogGroup{
+		LogEverything: everything,
+		LogAudit:      {LogSensitive, LogDQL, LogDDL, LogDML, LogPrivileged},
+	}
+}
+
+// logGroupsCoveredBy maps the log groups restricted to sensitive fields to
+// the log groups that enable the same setting for all requests.
+func logGroupsCoveredBy() map[LogGroup]LogGroup {
+	return map[LogGroup]LogGroup{
+		LogSensitiveDQL: LogDQL,
+		LogSensitiveDML: LogDML,
+		LogSensitiveDDL: LogDDL,
+	}
+}
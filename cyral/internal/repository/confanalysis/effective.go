@@ -0,0 +1,246 @@
+package confanalysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// EffectiveConfigSchema returns the schema of the configuration effectively
+// applied by the sidecar, computed from the settings of ConfigSchema.
+func EffectiveConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Configuration that is effectively applied by the sidecar, after resolving the settings " +
+			"that depend on other settings. For instance, `" + string(LogEverything) + "` and `" +
+			string(LogAudit) + "` are expanded into the log groups they enable, and `mask_all_occurrences` " +
+			"is only effective if `enable_data_masking` is also set.",
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				RedactKey: {
+					Description: "Redaction of literal values.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				AlertOnViolationKey: {
+					Description: "Whether policy violations raise alerts.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				BlockOnViolationKey: {
+					Description: "Whether queries that violate policies are blocked.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				"pre_configured_alerts": {
+					Description: "Whether preconfigured alerts are enabled.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				"filter_analysis": {
+					Description: "Whether filter analysis is enabled.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				"data_masking": {
+					Description: "Whether policies can mask data in the results of queries.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				MaskAllOccurrencesKey: {
+					Description: "Whether filtering conditions are also masked.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				"dataset_rewrites": {
+					Description: "Whether queries are rewritten.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+				CommentAnnotationGroupsKey: {
+					Description: "Groups added as comments to the queries, sorted by name.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				LogGroupsKey: {
+					Description: "Enabled log groups, without the groups that enable other groups and " +
+						"without the groups restricted to sensitive fields whose settings are also enabled " +
+						"for all requests.",
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// EffectiveConfigAsInterface returns the value of EffectiveConfigSchema for
+// the configuration.
+func (r *UserConfig) EffectiveConfigAsInterface() []interface{} {
+	annotationGroups := append([]string{}, r.CommentAnnotationGroups...)
+	sort.Strings(annotationGroups)
+	return []interface{}{
+		map[string]interface{}{
+			RedactKey:                  r.Redact,
+			AlertOnViolationKey:        r.AlertOnViolation,
+			BlockOnViolationKey:        r.BlockOnViolation,
+			"pre_configured_alerts":    !r.DisablePreConfiguredAlerts,
+			"filter_analysis":          !r.DisableFilterAnalysis,
+			"data_masking":             r.EnableDataMasking,
+			MaskAllOccurrencesKey:      r.EnableDataMasking && r.MaskAllOccurrences,
+			"dataset_rewrites":         r.EnableDatasetRewrites,
+			CommentAnnotationGroupsKey: annotationGroups,
+			LogGroupsKey:               effectiveLogGroups(r.LogGroups),
+		},
+	}
+}
+
+// effectiveLogGroups expands the log groups that enable other log groups and
+// removes the log groups covered by other ones. The result follows the order
+// of LogGroups, followed by unknown log groups sorted by name.
+func effectiveLogGroups(logGroups []string) []string {
+	aliases := logGroupAliases()
+	enabled := make(map[LogGroup]bool)
+	for _, logGroup := range logGroups {
+		if expanded, ok := aliases[LogGroup(logGroup)]; ok {
+			for _, g := range expanded {
+				enabled[g] = true
+			}
+			continue
+		}
+		enabled[LogGroup(logGroup)] = true
+	}
+	for logGroup, coveringLogGroup := range logGroupsCoveredBy() {
+		if enabled[coveringLogGroup] {
+			delete(enabled, logGroup)
+		}
+	}
+
+	var effective []string
+	for _, logGroup := range LogGroups() {
+		if enabled[logGroup] {
+			effective = append(effective, string(logGroup))
+			delete(enabled, logGroup)
+		}
+	}
+	var unknown []string
+	for logGroup := range enabled {
+		unknown = append(unknown, string(logGroup))
+	}
+	sort.Strings(unknown)
+	return append(effective, unknown...)
+}
+
+// contradictionWarnings returns warnings for settings that have no effect or
+// that probably do not behave as intended because of other settings.
+func (r *UserConfig) contradictionWarnings() diag.Diagnostics {
+	var diags diag.Diagnostics
+	warn := func(summary, detail string) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   detail,
+		})
+	}
+
+	if r.BlockOnViolation && !r.AlertOnViolation {
+		warn("Policy violations are blocked without alerts",
+			"`block_on_violation` is set but `alert_on_violation` is not, so queries that violate "+
+				"policies are blocked without raising alerts.")
+	}
+	if r.MaskAllOccurrences && !r.EnableDataMasking {
+		warn("`mask_all_occurrences` has no effect",
+			"`mask_all_occurrences` requires `enable_data_masking` to be set.")
+	}
+
+	enabled := make(map[LogGroup]bool)
+	for _, logGroup := range r.LogGroups {
+		enabled[LogGroup(logGroup)] = true
+	}
+	for _, alias := range []LogGroup{LogEverything, LogAudit} {
+		if !enabled[alias] {
+			continue
+		}
+		var redundant []string
+		for _, logGroup := range logGroupAliases()[alias] {
+			if enabled[logGroup] {
+				redundant = append(redundant, fmt.Sprintf("`%s`", logGroup))
+			}
+		}
+		if alias == LogEverything && enabled[LogAudit] {
+			redundant = append(redundant, fmt.Sprintf("`%s`", LogAudit))
+		}
+		if len(redundant) > 0 {
+			warn(fmt.Sprintf("Redundant log groups with `%s`", alias),
+				fmt.Sprintf("`%s` already enables %s.", alias, strings.Join(redundant, ", ")))
+		}
+	}
+	for _, logGroup := range []LogGroup{LogSensitiveDQL, LogSensitiveDML, LogSensitiveDDL} {
+		if coveringLogGroup := logGroupsCoveredBy()[logGroup]; enabled[logGroup] && enabled[coveringLogGroup] {
+			warn(fmt.Sprintf("Redundant log group `%s`", logGroup),
+				fmt.Sprintf("`%s` logs all requests, including the ones logged by `%s`.",
+					coveringLogGroup, logGroup))
+		}
+	}
+
+	return diags
+}
+
+// validateContradictions reports contradictory settings at plan time.
+func validateContradictions(
+	_ context.Context,
+	req schema.ValidateResourceConfigFuncRequest,
+	resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	resp.Diagnostics = append(resp.Diagnostics, ContradictionWarnings(req.RawConfig)...)
+}
+
+// ContradictionWarnings returns warnings for the contradictory settings of
+// the given raw configuration, which holds the settings of ConfigSchema. No
+// warnings are returned while any of the settings involved is unknown.
+func ContradictionWarnings(rawConfig cty.Value) diag.Diagnostics {
+	alertOnViolation, ok1 := utils.RawConfigBool(rawConfig, AlertOnViolationKey, true)
+	blockOnViolation, ok2 := utils.RawConfigBool(rawConfig, BlockOnViolationKey, false)
+	enableDataMasking, ok3 := utils.RawConfigBool(rawConfig, EnableDataMaskingKey, false)
+	maskAllOccurrences, ok4 := utils.RawConfigBool(rawConfig, MaskAllOccurrencesKey, false)
+	logGroups, ok5 := utils.RawConfigStrings(rawConfig, LogGroupsKey)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return nil
+	}
+	config := &UserConfig{
+		AlertOnViolation:   alertOnViolation,
+		BlockOnViolation:   blockOnViolation,
+		EnableDataMasking:  enableDataMasking,
+		MaskAllOccurrences: maskAllOccurrences,
+		LogGroups:          logGroups,
+	}
+	return config.contradictionWarnings()
+}
+
+// resourceRepositoryConfAnalysisCustomizeDiff computes the effective
+// configuration at plan time, so that settings without effect are shown in
+// the plan.
+func resourceRepositoryConfAnalysisCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range userConfigKeys() {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed(EffectiveConfigKey)
+		}
+	}
+	config := &UserConfig{}
+	config.readFrom(d)
+	return d.SetNew(EffectiveConfigKey, config.EffectiveConfigAsInterface())
+}
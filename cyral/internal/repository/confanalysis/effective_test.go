@@ -0,0 +1,159 @@
+package confanalysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveLogGroups(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		logGroups []string
+		expected  []string
+	}{
+		{
+			desc:      "no log groups",
+			logGroups: nil,
+			expected:  nil,
+		},
+		{
+			desc:      "log groups follow the order of LogGroups",
+			logGroups: []string{"violations", "dql"},
+			expected:  []string{"dql", "violations"},
+		},
+		{
+			desc:      "audit is expanded",
+			logGroups: []string{"audit"},
+			expected:  []string{"dql", "dml", "ddl", "privileged", "sensitive"},
+		},
+		{
+			desc:      "everything is expanded without the sensitive variants",
+			logGroups: []string{"everything", "dql"},
+			expected: []string{
+				"dql", "dml", "ddl", "privileged", "port-scan", "auth-failure", "full-table-scan",
+				"violations", "connections", "sensitive", "data-classification", "error",
+				"new-connections", "closed-connections",
+			},
+		},
+		{
+			desc:      "sensitive variant covered by the setting for all requests",
+			logGroups: []string{"sensitive & dql", "dql", "sensitive & dml"},
+			expected:  []string{"dql", "sensitive & dml"},
+		},
+		{
+			desc:      "unknown log groups are sorted at the end",
+			logGroups: []string{"zzz", "error", "aaa"},
+			expected:  []string{"error", "aaa", "zzz"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, effectiveLogGroups(testCase.logGroups))
+		})
+	}
+}
+
+func TestContradictionWarnings(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		config            UserConfig
+		expectedSummaries []string
+	}{
+		{
+			desc:   "consistent configuration",
+			config: UserConfig{AlertOnViolation: true, BlockOnViolation: true, LogGroups: []string{"dql"}},
+		},
+		{
+			desc:              "block without alert",
+			config:            UserConfig{BlockOnViolation: true},
+			expectedSummaries: []string{"Policy violations are blocked without alerts"},
+		},
+		{
+			desc:              "mask all occurrences without data masking",
+			config:            UserConfig{AlertOnViolation: true, MaskAllOccurrences: true},
+			expectedSummaries: []string{"`mask_all_occurrences` has no effect"},
+		},
+		{
+			desc:   "mask all occurrences with data masking",
+			config: UserConfig{AlertOnViolation: true, MaskAllOccurrences: true, EnableDataMasking: true},
+		},
+		{
+			desc: "log groups enabled by everything",
+			config: UserConfig{
+				AlertOnViolation: true,
+				LogGroups:        []string{"everything", "dql", "audit"},
+			},
+			expectedSummaries: []string{
+				"Redundant log groups with `everything`",
+				"Redundant log groups with `audit`",
+			},
+		},
+		{
+			desc:              "sensitive variant with the setting for all requests",
+			config:            UserConfig{AlertOnViolation: true, LogGroups: []string{"sensitive & ddl", "ddl"}},
+			expectedSummaries: []string{"Redundant log group `sensitive & ddl`"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var summaries []string
+			for _, d := range testCase.config.contradictionWarnings() {
+				summaries = append(summaries, d.Summary)
+			}
+			assert.Equal(t, testCase.expectedSummaries, summaries)
+		})
+	}
+}
+
+func TestValidateContradictions(t *testing.T) {
+	config := func(blockOnViolation cty.Value, logGroups cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"repository_id":        cty.StringVal("repo-id"),
+			"alert_on_violation":   cty.NullVal(cty.Bool),
+			"block_on_violation":   blockOnViolation,
+			"enable_data_masking":  cty.NullVal(cty.Bool),
+			"mask_all_occurrences": cty.NullVal(cty.Bool),
+			"log_groups":           logGroups,
+		})
+	}
+
+	testCases := []struct {
+		desc          string
+		rawConfig     cty.Value
+		expectedDiags int
+	}{
+		{
+			desc:      "alert on violation defaults to true",
+			rawConfig: config(cty.True, cty.NullVal(cty.Set(cty.String))),
+		},
+		{
+			desc: "redundant log groups",
+			rawConfig: config(cty.NullVal(cty.Bool),
+				cty.SetVal([]cty.Value{cty.StringVal("everything"), cty.StringVal("dql")})),
+			expectedDiags: 1,
+		},
+		{
+			desc: "unknown log groups skip the validation",
+			rawConfig: config(cty.NullVal(cty.Bool),
+				cty.SetVal([]cty.Value{cty.StringVal("everything"), cty.UnknownVal(cty.String)})),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateContradictions(
+				context.Background(),
+				schema.ValidateResourceConfigFuncRequest{RawConfig: testCase.rawConfig},
+				resp,
+			)
+			assert.Len(t, resp.Diagnostics, testCase.expectedDiags)
+		})
+	}
+}
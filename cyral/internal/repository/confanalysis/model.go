@@ -44,18 +44,18 @@ func (r *UserConfig) WriteToSchema(d *schema.ResourceData) error {
 	}
 	annotationGroupsSet := schema.NewSet(schema.HashString, annotationGroups)
 
-	d.Set("alert_on_violation", r.AlertOnViolation)
-	d.Set("block_on_violation", r.BlockOnViolation)
-	d.Set("comment_annotation_groups", annotationGroupsSet)
-	d.Set("disable_filter_analysis", r.DisableFilterAnalysis)
-	d.Set("disable_pre_configured_alerts", r.DisablePreConfiguredAlerts)
-	d.Set("enable_data_masking", r.EnableDataMasking)
-	d.Set("mask_all_occurrences", r.MaskAllOccurrences)
-	d.Set("log_groups", logGroupsSet)
-	d.Set("redact", r.Redact)
-	d.Set("enable_dataset_rewrites", r.EnableDatasetRewrites)
+	d.Set(AlertOnViolationKey, r.AlertOnViolation)
+	d.Set(BlockOnViolationKey, r.BlockOnViolation)
+	d.Set(CommentAnnotationGroupsKey, annotationGroupsSet)
+	d.Set(DisableFilterAnalysisKey, r.DisableFilterAnalysis)
+	d.Set(DisablePreConfiguredAlertsKey, r.DisablePreConfiguredAlerts)
+	d.Set(EnableDataMaskingKey, r.EnableDataMasking)
+	d.Set(MaskAllOccurrencesKey, r.MaskAllOccurrences)
+	d.Set(LogGroupsKey, logGroupsSet)
+	d.Set(RedactKey, r.Redact)
+	d.Set(EnableDatasetRewritesKey, r.EnableDatasetRewrites)
 
-	return nil
+	return d.Set(EffectiveConfigKey, r.EffectiveConfigAsInterface())
 }
 
 func (r *RepositoryConfAnalysisData) ReadFromSchema(d *schema.ResourceData) error {
@@ -63,30 +63,56 @@ func (r *RepositoryConfAnalysisData) ReadFromSchema(d *schema.ResourceData) erro
 }
 
 func (r *UserConfig) ReadFromSchema(d *schema.ResourceData) error {
+	r.readFrom(d)
+	return nil
+}
+
+// schemaGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so that the configuration can also be read at plan
+// time.
+type schemaGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+func userConfigKeys() []string {
+	return []string{
+		AlertOnViolationKey,
+		BlockOnViolationKey,
+		CommentAnnotationGroupsKey,
+		DisableFilterAnalysisKey,
+		DisablePreConfiguredAlertsKey,
+		EnableDataMaskingKey,
+		MaskAllOccurrencesKey,
+		LogGroupsKey,
+		RedactKey,
+		EnableDatasetRewritesKey,
+	}
+}
+
+func (r *UserConfig) readFrom(d schemaGetter) {
 	var logGroups []string
-	if logGroupsSet, ok := d.GetOk("log_groups"); ok {
+	if logGroupsSet, ok := d.GetOk(LogGroupsKey); ok {
 		for _, logGroupItem := range logGroupsSet.(*schema.Set).List() {
 			logGroups = append(logGroups, logGroupItem.(string))
 		}
 	}
 
 	var annotationGroups []string
-	if annotationGroupsSet, ok := d.GetOk("comment_annotation_groups"); ok {
+	if annotationGroupsSet, ok := d.GetOk(CommentAnnotationGroupsKey); ok {
 		for _, annotationGroupItem := range annotationGroupsSet.(*schema.Set).List() {
 			annotationGroups = append(annotationGroups, annotationGroupItem.(string))
 		}
 	}
 
-	r.AlertOnViolation = d.Get("alert_on_violation").(bool)
-	r.BlockOnViolation = d.Get("block_on_violation").(bool)
-	r.DisableFilterAnalysis = d.Get("disable_filter_analysis").(bool)
-	r.DisablePreConfiguredAlerts = d.Get("disable_pre_configured_alerts").(bool)
-	r.EnableDataMasking = d.Get("enable_data_masking").(bool)
-	r.MaskAllOccurrences = d.Get("mask_all_occurrences").(bool)
+	r.AlertOnViolation = d.Get(AlertOnViolationKey).(bool)
+	r.BlockOnViolation = d.Get(BlockOnViolationKey).(bool)
+	r.DisableFilterAnalysis = d.Get(DisableFilterAnalysisKey).(bool)
+	r.DisablePreConfiguredAlerts = d.Get(DisablePreConfiguredAlertsKey).(bool)
+	r.EnableDataMasking = d.Get(EnableDataMaskingKey).(bool)
+	r.MaskAllOccurrences = d.Get(MaskAllOccurrencesKey).(bool)
 	r.CommentAnnotationGroups = annotationGroups
 	r.LogGroups = logGroups
-	r.Redact = d.Get("redact").(string)
-	r.EnableDatasetRewrites = d.Get("enable_dataset_rewrites").(bool)
-
-	return nil
+	r.Redact = d.Get(RedactKey).(string)
+	r.EnableDatasetRewrites = d.Get(EnableDatasetRewritesKey).(bool)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // TODO This resource is more complex than it should be due to the fact that a call to
@@ -19,10 +20,12 @@ import (
 // Our API should be refactored so these operations should happen separately.
 
 var urlFactory = func(d *schema.ResourceData, c *client.Client) string {
-	return fmt.Sprintf("https://%s/v1/repos/%s/conf/analysis",
-		c.ControlPlane,
-		d.Get("repository_id"),
-	)
+	return URL(c, d.Get("repository_id").(string))
+}
+
+// URL returns the URL of the conf/analysis configuration of the given repository.
+func URL(c *client.Client, repoID string) string {
+	return fmt.Sprintf("https://%s/v1/repos/%s/conf/analysis", c.ControlPlane, repoID)
 }
 
 var resourceContextHandler = core.HTTPContextHandler{
@@ -57,6 +60,10 @@ func resourceSchema() *schema.Resource {
 			MessageMatches: "Cannot find config data for repo",
 			OperationType:  operationtype.Delete,
 		}),
+		CustomizeDiff: resourceRepositoryConfAnalysisCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateContradictions,
+		},
 
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -68,7 +75,7 @@ func resourceSchema() *schema.Resource {
 			},
 		},
 
-		Schema: repositoryConfAnalysisResourceSchemaV0().Schema,
+		Schema: repositoryConfAnalysisResourceSchema(),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: func(
@@ -83,110 +90,132 @@ func resourceSchema() *schema.Resource {
 	}
 }
 
+func repositoryConfAnalysisResourceSchema() map[string]*schema.Schema {
+	s := repositoryConfAnalysisResourceSchemaV0().Schema
+	s[EffectiveConfigKey] = EffectiveConfigSchema()
+	return s
+}
+
 func repositoryConfAnalysisResourceSchemaV0() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"id": {
+			Description: "ID of this resource in Cyral environment",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"repository_id": {
+			Description: "The ID of an existing data repository resource that will be configured.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+	}
+	for key, value := range ConfigSchema("") {
+		s[key] = value
+	}
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Description: "ID of this resource in Cyral environment",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"repository_id": {
-				Description: "The ID of an existing data repository resource that will be configured.",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"redact": {
-				Description:  "Valid values are: `all`, `none` and `watched`. If set to `all` it will enable the redact of all literal values, `none` will disable it, and `watched` will only redact values from tracked fields set in the Datamap.",
+		Schema: s,
+	}
+}
+
+// ConfigSchema returns the schema of the analysis settings of a repository.
+// It is shared with the `conf_analysis` block of the cyral_repository_stack
+// resource. path is the path of the block holding the settings, such as
+// `conf_analysis.0.`, and is used to refer to other settings. It is empty
+// for top-level settings.
+func ConfigSchema(path string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		RedactKey: {
+			Description:  "Valid values are: `all`, `none` and `watched`. If set to `all` it will enable the redact of all literal values, `none` will disable it, and `watched` will only redact values from tracked fields set in the Datamap.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(RedactAll),
+			ValidateFunc: validation.StringInSlice(RedactTypesAsString(), false),
+		},
+		AlertOnViolationKey: {
+			Description: "If set to `true` it will enable alert on policy violations.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		DisablePreConfiguredAlertsKey: {
+			Description: "If set to `true` it will *disable* preconfigured alerts.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		EnableDataMaskingKey: {
+			Description: "If set to `true` it will allow policies to force the masking " +
+				" of specified data fields in the results of queries. " +
+				"[Learn more](https://cyral.com/docs/using-cyral/masking/).",
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		MaskAllOccurrencesKey: {
+			Description: "If set to `true` it will also mask filtering conditions like in" +
+				" `WHERE`, `HAVING` or `ON` clauses. **Note**: Enabling this may cause some" +
+				" performance degradation on large tables. It is required to set" +
+				" `enable_data_masking=true` to use this feature, otherwise a warning is reported.",
+			Type:         schema.TypeBool,
+			Optional:     true,
+			Default:      false,
+			RequiredWith: []string{path + EnableDataMaskingKey},
+		},
+		BlockOnViolationKey: {
+			Description: "If set to `true` it will enable query blocking in case of a " +
+				"policy violation. A warning is reported if it is set without `alert_on_violation`.",
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		DisableFilterAnalysisKey: {
+			Description: "If set to `true` it will *disable* filter analysis.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		EnableDatasetRewritesKey: {
+			Description: "If set to `true` it will enable rewriting queries.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		CommentAnnotationGroupsKey: {
+			Description: "Valid values are: `identity`, `client`, `repo`, `sidecar`. The " +
+				"default behavior is to set only the `identity` when this option is " +
+				"enabled, but you can also opt to add the contents of `client`, `repo`, " +
+				" `sidecar` logging blocks as query comments. " +
+				" [Learn more](https://support.cyral.com/support/solutions/articles/44002218978).",
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
 				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      "all",
-				ValidateFunc: client.ValidateRepositoryConfAnalysisRedact(),
-			},
-			"alert_on_violation": {
-				Description: "If set to `true` it will enable alert on policy violations.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-			},
-			"disable_pre_configured_alerts": {
-				Description: "If set to `true` it will *disable* preconfigured alerts.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-			},
-			"enable_data_masking": {
-				Description: "If set to `true` it will allow policies to force the masking " +
-					" of specified data fields in the results of queries. " +
-					"[Learn more](https://cyral.com/docs/using-cyral/masking/).",
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
-			"mask_all_occurrences": {
-				Description: "If set to `true` it will also mask filtering conditions like in" +
-					" `WHERE`, `HAVING` or `ON` clauses. **Note**: Enabling this may cause some" +
-					" performance degradation on large tables. It is required to set" +
-					" `enable_data_masking=true` to use this feature.",
-				Type:         schema.TypeBool,
-				Optional:     true,
-				Default:      false,
-				RequiredWith: []string{"enable_data_masking"},
-			},
-			"block_on_violation": {
-				Description: "If set to `true` it will enable query blocking in case of a " +
-					"policy violation.",
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
-			"disable_filter_analysis": {
-				Description: "If set to `true` it will *disable* filter analysis.",
-				Type:        schema.TypeBool,
-				Optional:    true,
+				ValidateFunc: validation.StringInSlice(CommentAnnotationGroupsAsString(), false),
 			},
-			"enable_dataset_rewrites": {
-				Description: "If set to `true` it will enable rewriting queries.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-			},
-			"comment_annotation_groups": {
-				Description: "Valid values are: `identity`, `client`, `repo`, `sidecar`. The " +
-					"default behavior is to set only the `identity` when this option is " +
-					"enabled, but you can also opt to add the contents of `client`, `repo`, " +
-					" `sidecar` logging blocks as query comments. " +
-					" [Learn more](https://support.cyral.com/support/solutions/articles/44002218978).",
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: client.ValidateRepositoryConfAnalysisCommentAnnotationGroups(),
-				},
-			},
-			"log_groups": {
-				Description: "Responsible for configuring the Log Settings. Valid values are documented below. The `log_groups` list support the following values: " +
-					"\n  - `everything` - Enables all the Log Settings." +
-					"\n  - `dql` - Enables the `DQLs` setting for `all requests`." +
-					"\n  - `dml` - Enables the `DMLs` setting for `all requests`." +
-					"\n  - `ddl` - Enables the `DDLs` setting for `all requests`." +
-					"\n  - `sensitive & dql` - Enables the `DQLs` setting for `logged fields`." +
-					"\n  - `sensitive & dml` - Enables the `DMLs` setting for `logged fields`." +
-					"\n  - `sensitive & ddl` - Enables the `DDLs` setting for `logged fields`." +
-					"\n  - `privileged` - Enables the `Privileged commands` setting." +
-					"\n  - `port-scan` - Enables the `Port scans` setting." +
-					"\n  - `auth-failure` - Enables the `Authentication failures` setting." +
-					"\n  - `full-table-scan` - Enables the `Full scans` setting." +
-					"\n  - `violations` - Enables the `Policy violations` setting." +
-					"\n  - `connections` - Enables the `Connection activity` setting." +
-					"\n  - `sensitive` - Log all queries manipulating sensitive fields (watches)" +
-					"\n  - `data-classification` - Log all queries whose response was automatically classified as sensitive (credit card numbers, emails and so on)." +
-					"\n  - `audit` - Log `sensitive`, `DQLs`, `DDLs`, `DMLs` and `privileged`." +
-					"\n  - `error` - Log analysis errors." +
-					"\n  - `new-connections` - Log new connections." +
-					"\n  - `closed-connections` - Log closed connections.",
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: client.ValidateRepositoryConfAnalysisLogSettings(),
-				},
+		},
+		LogGroupsKey: {
+			Description: "Responsible for configuring the Log Settings. Valid values are documented below. The `log_groups` list support the following values: " +
+				"\n  - `everything` - Enables all the Log Settings." +
+				"\n  - `dql` - Enables the `DQLs` setting for `all requests`." +
+				"\n  - `dml` - Enables the `DMLs` setting for `all requests`." +
+				"\n  - `ddl` - Enables the `DDLs` setting for `all requests`." +
+				"\n  - `sensitive & dql` - Enables the `DQLs` setting for `logged fields`." +
+				"\n  - `sensitive & dml` - Enables the `DMLs` setting for `logged fields`." +
+				"\n  - `sensitive & ddl` - Enables the `DDLs` setting for `logged fields`." +
+				"\n  - `privileged` - Enables the `Privileged commands` setting." +
+				"\n  - `port-scan` - Enables the `Port scans` setting." +
+				"\n  - `auth-failure` - Enables the `Authentication failures` setting." +
+				"\n  - `full-table-scan` - Enables the `Full scans` setting." +
+				"\n  - `violations` - Enables the `Policy violations` setting." +
+				"\n  - `connections` - Enables the `Connection activity` setting." +
+				"\n  - `sensitive` - Log all queries manipulating sensitive fields (watches)" +
+				"\n  - `data-classification` - Log all queries whose response was automatically classified as sensitive (credit card numbers, emails and so on)." +
+				"\n  - `audit` - Log `sensitive`, `DQLs`, `DDLs`, `DMLs` and `privileged`." +
+				"\n  - `error` - Log analysis errors." +
+				"\n  - `new-connections` - Log new connections." +
+				"\n  - `closed-connections` - Log closed connections." +
+				"\n\nA warning is reported for log groups that are already enabled by other log groups," +
+				" such as `dql` together with `everything`.",
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(LogGroupsAsString(), false),
 			},
 		},
 	}
@@ -242,13 +271,29 @@ func resourceRepositoryConfAnalysisCreate(
 }
 
 func confAnalysisAlreadyExists(ctx context.Context, c *client.Client, d *schema.ResourceData) bool {
-	_, err := c.DoRequest(ctx, urlFactory(d, c), http.MethodGet, nil)
+	return AlreadyExists(ctx, c, d.Get("repository_id").(string))
+}
+
+// AlreadyExists reports whether the conf/analysis configuration of the given
+// repository exists, in which case it must be updated with PUT instead of
+// created with POST.
+func AlreadyExists(ctx context.Context, c *client.Client, repoID string) bool {
+	_, err := c.DoRequest(ctx, URL(c, repoID), http.MethodGet, nil)
 	// See TODO on the top of this file
 	if err != nil {
 
 		tflog.Debug(ctx, fmt.Sprintf("Unable to read Conf Analysis resource for repository %s: %v",
-			d.Get("repository_id").(string), err))
+			repoID, err))
 		return false
 	}
 	return true
 }
+
+// UpsertMethod returns the HTTP method used to write the conf/analysis
+// configuration of the given repository.
+func UpsertMethod(ctx context.Context, c *client.Client, repoID string) string {
+	if AlreadyExists(ctx, c, repoID) {
+		return http.MethodPut
+	}
+	return http.MethodPost
+}
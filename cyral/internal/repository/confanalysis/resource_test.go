@@ -144,6 +144,14 @@ func testAccRepoConfAnalysisCheck_DefaultValues() resource.TestCheckFunc {
 			"redact", "all"),
 		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
 			"enable_dataset_rewrites", "false"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.redact", "all"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.pre_configured_alerts", "true"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.filter_analysis", "true"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.log_groups.#", "0"),
 	)
 }
 
@@ -201,5 +209,18 @@ func testAccRepoConfAnalysisCheck_Updated() resource.TestCheckFunc {
 			"log_groups.*", "sensitive & dml"),
 		resource.TestCheckTypeSetElemAttr("cyral_repository_conf_analysis.test_conf_analysis",
 			"log_groups.*", "sensitive & ddl"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.data_masking", "true"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.mask_all_occurrences", "false"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.comment_annotation_groups.#", "1"),
+		// `everything` enables all the log groups, and the log groups
+		// restricted to sensitive fields are covered by `dql`, `dml` and
+		// `ddl`.
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.log_groups.#", "14"),
+		resource.TestCheckResourceAttr("cyral_repository_conf_analysis.test_conf_analysis",
+			"effective_config.0.log_groups.0", "dql"),
 	)
 }
@@ -9,6 +9,15 @@ const (
 	DefaultAuthType     = AccessTokenAuthType
 )
 
+const (
+	// Schema keys
+	AllowNativeAuthKey  = "allow_native_auth"
+	ClientTLSKey        = "client_tls"
+	RepoTLSKey          = "repo_tls"
+	IdentityProviderKey = "identity_provider"
+	AuthTypeKey         = "auth_type"
+)
+
 const (
 	TLSEnable              = TLSType("enable")
 	TLSEnableAndVerifyCert = TLSType("enableAndVerifyCert")
@@ -23,11 +23,11 @@ func (data RepositoryConfAuthData) WriteToSchema(d *schema.ResourceData) error {
 		d.Set("repository_id", data.RepoID)
 	}
 
-	d.Set("allow_native_auth", data.AllowNativeAuth)
-	d.Set("client_tls", data.ClientTLS)
-	d.Set("identity_provider", data.IdentityProvider)
-	d.Set("repo_tls", data.RepoTLS)
-	d.Set("auth_type", data.AuthType)
+	d.Set(AllowNativeAuthKey, data.AllowNativeAuth)
+	d.Set(ClientTLSKey, data.ClientTLS)
+	d.Set(IdentityProviderKey, data.IdentityProvider)
+	d.Set(RepoTLSKey, data.RepoTLS)
+	d.Set(AuthTypeKey, data.AuthType)
 
 	return nil
 }
@@ -38,11 +38,11 @@ func (data *RepositoryConfAuthData) ReadFromSchema(d *schema.ResourceData) error
 		data.RepoID = &repoId
 	}
 
-	data.AllowNativeAuth = d.Get("allow_native_auth").(bool)
-	data.AuthType = d.Get("auth_type").(string)
-	data.ClientTLS = d.Get("client_tls").(string)
-	data.IdentityProvider = d.Get("identity_provider").(string)
-	data.RepoTLS = d.Get("repo_tls").(string)
+	data.AllowNativeAuth = d.Get(AllowNativeAuthKey).(bool)
+	data.AuthType = d.Get(AuthTypeKey).(string)
+	data.ClientTLS = d.Get(ClientTLSKey).(string)
+	data.IdentityProvider = d.Get(IdentityProviderKey).(string)
+	data.RepoTLS = d.Get(RepoTLSKey).(string)
 
 	return nil
 }
@@ -22,10 +22,12 @@ import (
 // Our API should be refactored so these operations should happen separately.
 
 var urlFactory = func(d *schema.ResourceData, c *client.Client) string {
-	return fmt.Sprintf("https://%s/v1/repos/%s/conf/auth",
-		c.ControlPlane,
-		d.Get("repository_id"),
-	)
+	return URL(c, d.Get("repository_id").(string))
+}
+
+// URL returns the URL of the conf/auth configuration of the given repository.
+func URL(c *client.Client, repoID string) string {
+	return fmt.Sprintf("https://%s/v1/repos/%s/conf/auth", c.ControlPlane, repoID)
 }
 
 var resourceContextHandler = core.HTTPContextHandler{
@@ -85,67 +87,79 @@ func resourceSchema() *schema.Resource {
 }
 
 func repositoryConfAuthResourceSchemaV0() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"id": {
+			Description: "The ID of this resource is set to `repository_id`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"repository_id": {
+			Description: "The ID of the repository to be configured.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+	}
+	for key, value := range ConfigSchema() {
+		s[key] = value
+	}
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Description: "The ID of this resource is set to `repository_id`.",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"repository_id": {
-				Description: "The ID of the repository to be configured.",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"allow_native_auth": {
-				Description: "Should the communication allow native authentication?",
-				Type:        schema.TypeBool,
-				Optional:    true,
-			},
-			"client_tls": {
-				Description: fmt.Sprintf(
-					"Specifies whether the sidecar will require TLS communication with clients."+
-						" Defaults to `%s`. List of supported values: %s", TLSDisable, utils.SupportedValuesAsMarkdown(ClientTLSTypesAsString())),
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      TLSDisable,
-				ValidateFunc: validation.StringInSlice(append(ClientTLSTypesAsString(), ""), false),
-			},
-			"identity_provider": {
-				Description: fmt.Sprintf(
-					"The semantics of this field changed in control planes `v4.13` and later. See how "+
-						"it should be configured depending on your control plane version:\n"+
-						"\t- `v4.12` and below:\n\t\t- Provide the ID (Alias) of the identity provider "+
-						"integration to allow user authentication using an IdP.\n"+
-						"\t- `v4.13` and later:\n\t\t- If not supplied, then end-user "+
-						"authentication is disabled.\n\t\t- If end-user authentication "+
-						"with Cyral Access Token is desired, then set to `ACCESS_TOKEN` or any "+
-						"other non-empty string.\n\t\t- If end-user authentication with "+
-						"AWS IAM is desired, then this must be the ID of an AWS IAM integration, "+
-						"and the `auth_type` attribute must be set to `%s`.",
-					AwsIAMAuthType,
-				),
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"repo_tls": {
-				Description: fmt.Sprintf(
-					"Specifies whether the sidecar will communicate with the repository using TLS."+
-						" Defaults to `%s`. List of supported values: %s", TLSDisable, utils.SupportedValuesAsMarkdown(RepoTLSTypesAsString())),
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      TLSDisable,
-				ValidateFunc: validation.StringInSlice(append(RepoTLSTypesAsString(), ""), false),
-			},
-			"auth_type": {
-				Description: fmt.Sprintf("Authentication type for this repository. **Note**: `%s` is currently "+
-					"only supported by `%s` repo type. List of supported values: %s",
-					AwsIAMAuthType, repository.MongoDB, utils.SupportedValuesAsMarkdown(authTypes)),
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      DefaultAuthType,
-				ValidateFunc: validation.StringInSlice(authTypes, false),
-			},
+		Schema: s,
+	}
+}
+
+// ConfigSchema returns the schema of the authentication settings of a
+// repository. It is shared with the `conf_auth` block of the
+// cyral_repository_stack resource.
+func ConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		AllowNativeAuthKey: {
+			Description: "Should the communication allow native authentication?",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		ClientTLSKey: {
+			Description: fmt.Sprintf(
+				"Specifies whether the sidecar will require TLS communication with clients."+
+					" Defaults to `%s`. List of supported values: %s", TLSDisable, utils.SupportedValuesAsMarkdown(ClientTLSTypesAsString())),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      TLSDisable,
+			ValidateFunc: validation.StringInSlice(append(ClientTLSTypesAsString(), ""), false),
+		},
+		IdentityProviderKey: {
+			Description: fmt.Sprintf(
+				"The semantics of this field changed in control planes `v4.13` and later. See how "+
+					"it should be configured depending on your control plane version:\n"+
+					"\t- `v4.12` and below:\n\t\t- Provide the ID (Alias) of the identity provider "+
+					"integration to allow user authentication using an IdP.\n"+
+					"\t- `v4.13` and later:\n\t\t- If not supplied, then end-user "+
+					"authentication is disabled.\n\t\t- If end-user authentication "+
+					"with Cyral Access Token is desired, then set to `ACCESS_TOKEN` or any "+
+					"other non-empty string.\n\t\t- If end-user authentication with "+
+					"AWS IAM is desired, then this must be the ID of an AWS IAM integration, "+
+					"and the `auth_type` attribute must be set to `%s`.",
+				AwsIAMAuthType,
+			),
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		RepoTLSKey: {
+			Description: fmt.Sprintf(
+				"Specifies whether the sidecar will communicate with the repository using TLS."+
+					" Defaults to `%s`. List of supported values: %s", TLSDisable, utils.SupportedValuesAsMarkdown(RepoTLSTypesAsString())),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      TLSDisable,
+			ValidateFunc: validation.StringInSlice(append(RepoTLSTypesAsString(), ""), false),
+		},
+		AuthTypeKey: {
+			Description: fmt.Sprintf("Authentication type for this repository. **Note**: `%s` is currently "+
+				"only supported by `%s` repo type. List of supported values: %s",
+				AwsIAMAuthType, repository.MongoDB, utils.SupportedValuesAsMarkdown(authTypes)),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      DefaultAuthType,
+			ValidateFunc: validation.StringInSlice(authTypes, false),
 		},
 	}
 }
@@ -200,13 +214,29 @@ func resourceRepositoryConfAuthCreate(
 }
 
 func confAuthAlreadyExists(ctx context.Context, c *client.Client, d *schema.ResourceData) bool {
-	_, err := c.DoRequest(ctx, urlFactory(d, c), http.MethodGet, nil)
+	return AlreadyExists(ctx, c, d.Get("repository_id").(string))
+}
+
+// AlreadyExists reports whether the conf/auth configuration of the given
+// repository exists, in which case it must be updated with PUT instead of
+// created with POST.
+func AlreadyExists(ctx context.Context, c *client.Client, repoID string) bool {
+	_, err := c.DoRequest(ctx, URL(c, repoID), http.MethodGet, nil)
 	// See TODO on the top of this file
 	if err != nil {
 
 		tflog.Debug(ctx, fmt.Sprintf("Unable to read Conf Auth resource for repository %s: %v",
-			d.Get("repository_id").(string), err))
+			repoID, err))
 		return false
 	}
 	return true
 }
+
+// UpsertMethod returns the HTTP method used to write the conf/auth
+// configuration of the given repository.
+func UpsertMethod(ctx context.Context, c *client.Client, repoID string) string {
+	if AlreadyExists(ctx, c, repoID) {
+		return http.MethodPut
+	}
+	return http.MethodPost
+}
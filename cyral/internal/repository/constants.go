@@ -31,6 +31,27 @@ const (
 	RepoRedshiftClusterIdentifier = "cluster_identifier"
 	RepoRedshiftWorkgroupName     = "workgroup_name"
 	RepoRedshiftAWSRegion         = "aws_region"
+	// Oracle settings keys.
+	RepoOracleSettingsKey    = "oracle_settings"
+	RepoOracleServiceNameKey = "service_name"
+	RepoOracleSIDKey         = "sid"
+	// Snowflake settings keys.
+	RepoSnowflakeSettingsKey  = "snowflake_settings"
+	RepoSnowflakeAccountKey   = "account"
+	RepoSnowflakeWarehouseKey = "warehouse"
+	// SQL Server settings keys.
+	RepoSQLServerSettingsKey     = "sqlserver_settings"
+	RepoSQLServerInstanceNameKey = "instance_name"
+	// PostgreSQL settings keys.
+	RepoPostgreSQLSettingsKey          = "postgresql_settings"
+	RepoPostgreSQLSSLModeKey           = "ssl_mode"
+	RepoPostgreSQLClusterIdentifierKey = "cluster_identifier"
+	RepoPostgreSQLAWSRegionKey         = "aws_region"
+	// S3 and DynamoDB settings keys.
+	RepoS3SettingsKey       = "s3_settings"
+	RepoDynamoDBSettingsKey = "dynamodb_settings"
+	RepoAWSRegionKey        = "aws_region"
+	RepoAWSEndpointKey      = "endpoint"
 )
 
 const (
@@ -94,3 +115,30 @@ func mongoFlavors() []string {
 		MongoDBFlavorDocumentDB,
 	}
 }
+
+const (
+	SSLModeDisable    = "disable"
+	SSLModeAllow      = "allow"
+	SSLModePrefer     = "prefer"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+func postgreSQLSSLModes() []string {
+	return []string{
+		SSLModeDisable,
+		SSLModeAllow,
+		SSLModePrefer,
+		SSLModeRequire,
+		SSLModeVerifyCA,
+		SSLModeVerifyFull,
+	}
+}
+
+func dynamoDBTypes() []string {
+	return []string{
+		DynamoDB,
+		DynamoDBStreams,
+	}
+}
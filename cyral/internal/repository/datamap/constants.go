@@ -1,5 +1,16 @@
 package datamap
 
 const (
-	resourceName = "cyral_repository_datamap"
+	resourceName   = "cyral_repository_datamap"
+	dataSourceName = "cyral_repository_datamap"
+)
+
+const (
+	// Schema keys.
+	MappingKey        = "mapping"
+	LabelKey          = "label"
+	AttributesKey     = "attributes"
+	DesiredMappingKey = "desired_mapping"
+	MissingLabelsKey  = "missing_labels"
+	MissingMappingKey = "missing_mapping"
 )
@@ -0,0 +1,205 @@
+package datamap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the [Data Map](https://cyral.com/docs/policy/datamap) of a repository, " +
+			"including the mappings discovered by the repository crawler." +
+			"\n\nIf `" + DesiredMappingKey + "` is set, the data source also reports the labels and " +
+			"attributes that exist in the control plane but are missing from it, which allows " +
+			"reviewing the crawler findings before adding them to a " +
+			"[`cyral_repository_datamap`](../resources/repository_datamap.md) resource.",
+		ReadContext: dataSourceRepositoryDatamapRead,
+		Schema: map[string]*schema.Schema{
+			utils.RepositoryIDKey: {
+				Description: "ID of the repository to retrieve the data map from.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			DesiredMappingKey: {
+				Description: "Mappings expected to exist in the data map, usually the same mappings of the " +
+					"corresponding `cyral_repository_datamap` resource. Used to compute `" +
+					MissingLabelsKey + "` and `" + MissingMappingKey + "`.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						LabelKey: {
+							Description: "Label given to the attributes in this mapping.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						AttributesKey: {
+							Description: "List containing the specific locations of the data within the repo.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			MappingKey: {
+				Description: "All the mappings of the data map, whether they were added by the repository " +
+					"crawler or by a user.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     computedMappingSchema(),
+			},
+			MissingLabelsKey: {
+				Description: "Labels that exist in the data map but not in `" + DesiredMappingKey + "`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			MissingMappingKey: {
+				Description: "Attributes that exist in the data map but not in `" + DesiredMappingKey +
+					"`, grouped by label. Includes the attributes of the labels in `" + MissingLabelsKey + "`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     computedMappingSchema(),
+			},
+		},
+	}
+}
+
+func computedMappingSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			LabelKey: {
+				Description: "Label given to the attributes in this mapping.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			AttributesKey: {
+				Description: "List containing the specific locations of the data within the repo.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepositoryDatamapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceRepositoryDatamapRead")
+	c := m.(*client.Client)
+	repoID := d.Get(utils.RepositoryIDKey).(string)
+
+	current := &DataMap{}
+	url := fmt.Sprintf("https://%s/v1/repos/%s/datamap", c.ControlPlane, repoID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		// Repositories without a data map are reported as not found.
+		if !client.IsNotFound(err) {
+			return utils.CreateError(fmt.Sprintf("Unable to read data map of repository %q", repoID), err.Error())
+		}
+	} else if err := json.Unmarshal(body, current); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read data map of repository %q", repoID), err.Error())
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", current))
+
+	desired := DataMap{Labels: make(map[string]*DataMapMapping)}
+	for _, mappingIface := range d.Get(DesiredMappingKey).(*schema.Set).List() {
+		mapping := mappingIface.(map[string]interface{})
+		desired.Labels[mapping[LabelKey].(string)] = &DataMapMapping{
+			Attributes: utils.ConvertFromInterfaceList[string](mapping[AttributesKey].([]interface{})),
+		}
+	}
+	missing := current.missingFrom(&desired)
+
+	var missingLabels []string
+	for _, label := range missing.sortedLabels() {
+		if _, ok := desired.Labels[label]; !ok {
+			missingLabels = append(missingLabels, label)
+		}
+	}
+
+	if err := d.Set(MappingKey, current.mappingsAsInterface()); err != nil {
+		return utils.CreateError("Unable to read data map", fmt.Errorf(utils.ErrorSettingFieldFmt, MappingKey, err).Error())
+	}
+	if err := d.Set(MissingLabelsKey, missingLabels); err != nil {
+		return utils.CreateError("Unable to read data map", fmt.Errorf(utils.ErrorSettingFieldFmt, MissingLabelsKey, err).Error())
+	}
+	if err := d.Set(MissingMappingKey, missing.mappingsAsInterface()); err != nil {
+		return utils.CreateError("Unable to read data map", fmt.Errorf(utils.ErrorSettingFieldFmt, MissingMappingKey, err).Error())
+	}
+	d.SetId(repoID)
+
+	tflog.Debug(ctx, "End dataSourceRepositoryDatamapRead")
+	return nil
+}
+
+// missingFrom returns the attributes of the data map that are not present
+// in the other data map, grouped by label. Labels without missing
+// attributes are omitted.
+func (dm *DataMap) missingFrom(other *DataMap) *DataMap {
+	missing := &DataMap{Labels: make(map[string]*DataMapMapping)}
+	for label, mapping := range dm.Labels {
+		existing := make(map[string]bool)
+		if otherMapping, ok := other.Labels[label]; ok && otherMapping != nil {
+			for _, attribute := range otherMapping.Attributes {
+				existing[attribute] = true
+			}
+		}
+		var attributes []string
+		if mapping != nil {
+			for _, attribute := range mapping.Attributes {
+				if !existing[attribute] {
+					attributes = append(attributes, attribute)
+				}
+			}
+		}
+		_, labelExists := other.Labels[label]
+		if len(attributes) > 0 || !labelExists {
+			missing.Labels[label] = &DataMapMapping{Attributes: attributes}
+		}
+	}
+	return missing
+}
+
+func (dm *DataMap) sortedLabels() []string {
+	labels := make([]string, 0, len(dm.Labels))
+	for label := range dm.Labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// mappingsAsInterface returns the mappings sorted by label, with sorted
+// attributes, so that the data source output is deterministic.
+func (dm *DataMap) mappingsAsInterface() []interface{} {
+	mappings := make([]interface{}, 0, len(dm.Labels))
+	for _, label := range dm.sortedLabels() {
+		var attributes []string
+		if mapping := dm.Labels[label]; mapping != nil {
+			attributes = append(attributes, mapping.Attributes...)
+		}
+		sort.Strings(attributes)
+		mappings = append(mappings, map[string]interface{}{
+			LabelKey:      label,
+			AttributesKey: attributes,
+		})
+	}
+	return mappings
+}
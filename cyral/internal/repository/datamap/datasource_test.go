@@ -0,0 +1,57 @@
+package datamap_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/datamap"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRepositoryDatamapDataSource(t *testing.T) {
+	resName := "test_data_source"
+	dataMap := &datamap.DataMap{
+		Labels: map[string]*datamap.DataMapMapping{
+			predefinedLabelCCN: {
+				Attributes: []string{"schema1.table1.col1", "schema1.table1.col2"},
+			},
+			predefinedLabelSSN: {
+				Attributes: []string{"schema1.table2.col1"},
+			},
+		},
+	}
+	config := repositoryDatamapSampleRepositoryConfig(resName)
+	config += formatDataMapIntoConfig(resName, utils.BasicRepositoryID, dataMap)
+	config += fmt.Sprintf(`
+	data "cyral_repository_datamap" "test" {
+		repository_id = cyral_repository_datamap.%s.repository_id
+		desired_mapping {
+			label      = "%s"
+			attributes = ["schema1.table1.col1"]
+		}
+	}`, resName, predefinedLabelCCN)
+
+	dataSourceFullName := "data.cyral_repository_datamap.test"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "mapping.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "mapping.0.label", predefinedLabelCCN),
+					resource.TestCheckResourceAttr(dataSourceFullName, "mapping.0.attributes.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_labels.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_labels.0", predefinedLabelSSN),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_mapping.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_mapping.0.label", predefinedLabelCCN),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_mapping.0.attributes.0", "schema1.table1.col2"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_mapping.1.label", predefinedLabelSSN),
+					resource.TestCheckResourceAttr(dataSourceFullName, "missing_mapping.1.attributes.0", "schema1.table2.col1"),
+				),
+			},
+		},
+	})
+}
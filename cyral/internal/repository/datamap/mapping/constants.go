@@ -0,0 +1,5 @@
+package mapping
+
+const (
+	resourceName = "cyral_repository_datamap_mapping"
+)
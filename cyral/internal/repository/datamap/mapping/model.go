@@ -0,0 +1,85 @@
+package mapping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/datamap"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// lockRepository serializes the read-modify-write cycles on the data map of
+// the repository, so that concurrent operations on different labels of the
+// same repository don't overwrite each other.
+func lockRepository(repoID string) func() {
+	return utils.LockKey("repository_datamap/" + repoID)
+}
+
+func dataMapURL(c *client.Client, repoID string) string {
+	return fmt.Sprintf("https://%s/v1/repos/%s/datamap", c.ControlPlane, repoID)
+}
+
+// getDataMap retrieves the data map of the repository. A repository without
+// a data map is returned as an empty data map.
+func getDataMap(ctx context.Context, c *client.Client, repoID string) (*datamap.DataMap, error) {
+	dm := &datamap.DataMap{}
+	body, err := c.DoRequest(ctx, dataMapURL(c, repoID), http.MethodGet, nil)
+	if err != nil {
+		if client.IsNotFound(err) {
+			body = nil
+		} else {
+			return nil, err
+		}
+	}
+	if body != nil {
+		if err := json.Unmarshal(body, dm); err != nil {
+			return nil, err
+		}
+	}
+	if dm.Labels == nil {
+		dm.Labels = make(map[string]*datamap.DataMapMapping)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", dm))
+	return dm, nil
+}
+
+// putDataMap replaces the data map of the repository. An empty data map is
+// deleted instead, as the API does not accept data maps without labels.
+func putDataMap(ctx context.Context, c *client.Client, repoID string, dm *datamap.DataMap) error {
+	if len(dm.Labels) == 0 {
+		_, err := c.DoRequest(ctx, dataMapURL(c, repoID), http.MethodDelete, nil)
+		if client.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := c.DoRequest(ctx, dataMapURL(c, repoID), http.MethodPut, &datamap.DataMapRequest{DataMap: *dm})
+	return err
+}
+
+// updateLabel sets the attributes of a single label of the data map of the
+// repository, keeping the other labels untouched. Nil attributes remove the
+// label from the data map.
+func updateLabel(ctx context.Context, c *client.Client, repoID, label string, attributes []string) error {
+	unlock := lockRepository(repoID)
+	defer unlock()
+
+	dm, err := getDataMap(ctx, c, repoID)
+	if err != nil {
+		return err
+	}
+	if attributes == nil {
+		if _, ok := dm.Labels[label]; !ok {
+			return nil
+		}
+		delete(dm.Labels, label)
+	} else {
+		dm.Labels[label] = &datamap.DataMapMapping{Attributes: attributes}
+	}
+	return putDataMap(ctx, c, repoID, dm)
+}
@@ -0,0 +1,177 @@
+package mapping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/datamap"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func resourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the attributes of a single label of the [Data Map](https://cyral.com/docs/policy/datamap) " +
+			"of a repository, without changing the other labels of the data map." +
+			"\n\nThis allows different configurations to manage different labels of the same repository. " +
+			"It must not be combined with the [`cyral_repository_datamap`](./repository_datamap.md) " +
+			"resource for the same repository, as the latter manages the whole data map." +
+			"\n\nEach change reads the whole data map, updates the label and writes the data map back. " +
+			"Changes to labels of the same repository are serialized within a single Terraform run, but " +
+			"not across runs that apply concurrently, such as runs of different configurations or " +
+			"workspaces. Such runs must not apply changes to the same repository at the same time, " +
+			"otherwise the changes of one of them may be lost.",
+		CreateContext: resourceRepositoryDatamapMappingCreate,
+		ReadContext:   resourceRepositoryDatamapMappingRead,
+		UpdateContext: resourceRepositoryDatamapMappingUpdate,
+		DeleteContext: resourceRepositoryDatamapMappingDelete,
+		Schema: map[string]*schema.Schema{
+			utils.IDKey: {
+				Description: "ID of this resource in Terraform state, in the format `{repository_id}/{label}`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			utils.RepositoryIDKey: {
+				Description: "ID of the repository for which to configure the label.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			datamap.LabelKey: {
+				Description: "Label given to the attributes.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			datamap.AttributesKey: {
+				Description: "List containing the specific locations of the data within the repo. See the " +
+					"[`cyral_repository_datamap`](./repository_datamap.md) resource for the expected format " +
+					"for each repository type.",
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(
+				ctx context.Context,
+				d *schema.ResourceData,
+				m interface{},
+			) ([]*schema.ResourceData, error) {
+				ids, err := utils.UnMarshalComposedID(d.Id(), "/", 2)
+				if err != nil {
+					return nil, err
+				}
+				d.Set(utils.RepositoryIDKey, ids[0])
+				// Labels may contain the separator.
+				d.Set(datamap.LabelKey, strings.Join(ids[1:], "/"))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+func resourceRepositoryDatamapMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryDatamapMappingCreate")
+	if diags := setLabelAttributes(ctx, d, m.(*client.Client)); diags.HasError() {
+		return diags
+	}
+	d.SetId(utils.MarshalComposedID([]string{
+		d.Get(utils.RepositoryIDKey).(string),
+		d.Get(datamap.LabelKey).(string),
+	}, "/"))
+	tflog.Debug(ctx, "End resourceRepositoryDatamapMappingCreate")
+	return resourceRepositoryDatamapMappingRead(ctx, d, m)
+}
+
+func resourceRepositoryDatamapMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryDatamapMappingRead")
+	c := m.(*client.Client)
+	repoID := d.Get(utils.RepositoryIDKey).(string)
+	label := d.Get(datamap.LabelKey).(string)
+
+	dm, err := getDataMap(ctx, c, repoID)
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+	}
+	mapping, ok := dm.Labels[label]
+	if !ok || mapping == nil || len(mapping.Attributes) == 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Label %q not found in data map of repository %q, removing %s from state.",
+			label, repoID, resourceName))
+		d.SetId("")
+		return nil
+	}
+
+	// The API does not return the attributes in a deterministic order, so
+	// they are only written to the state if they actually changed.
+	current := utils.ConvertFromInterfaceList[string](d.Get(datamap.AttributesKey).([]interface{}))
+	if !utils.ElementsMatch(current, mapping.Attributes) {
+		if err := d.Set(datamap.AttributesKey, mapping.Attributes); err != nil {
+			return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName),
+				fmt.Errorf(utils.ErrorSettingFieldFmt, datamap.AttributesKey, err).Error())
+		}
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryDatamapMappingRead")
+	return nil
+}
+
+func resourceRepositoryDatamapMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryDatamapMappingUpdate")
+	if diags := setLabelAttributes(ctx, d, m.(*client.Client)); diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "End resourceRepositoryDatamapMappingUpdate")
+	return resourceRepositoryDatamapMappingRead(ctx, d, m)
+}
+
+func resourceRepositoryDatamapMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryDatamapMappingDelete")
+	c := m.(*client.Client)
+	repoID := d.Get(utils.RepositoryIDKey).(string)
+	label := d.Get(datamap.LabelKey).(string)
+
+	if err := updateLabel(ctx, c, repoID, label, nil); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to delete %s", resourceName), err.Error())
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryDatamapMappingDelete")
+	return nil
+}
+
+// setLabelAttributes validates the attributes against the repository type
+// and writes them to the label of the data map.
+func setLabelAttributes(ctx context.Context, d *schema.ResourceData, c *client.Client) diag.Diagnostics {
+	repoID := d.Get(utils.RepositoryIDKey).(string)
+	label := d.Get(datamap.LabelKey).(string)
+	attributes := utils.ConvertFromInterfaceList[string](d.Get(datamap.AttributesKey).([]interface{}))
+
+	repoType, err := datamap.GetRepositoryType(ctx, c, repoID)
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to retrieve the type of repository %q", repoID), err.Error())
+	}
+	var errs []error
+	for _, attribute := range attributes {
+		if err := datamap.ValidateAttribute(repoType, attribute); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utils.CreateError(fmt.Sprintf("Invalid attributes in mapping for label %q", label),
+			errors.Join(errs...).Error())
+	}
+
+	if err := updateLabel(ctx, c, repoID, label, attributes); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to set attributes of label %q", label), err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package mapping_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	repositoryDatamapMappingResourceName = "repository-datamap-mapping"
+)
+
+func TestAccRepositoryDatamapMappingResource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryDatamapMappingConfig(
+					[]string{"schema1.table1.col1"},
+					[]string{"schema1.table2.col1"},
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cyral_repository_datamap_mapping.ccn", "attributes.#", "1"),
+					resource.TestCheckResourceAttr("cyral_repository_datamap_mapping.ssn", "attributes.#", "1"),
+				),
+			},
+			{
+				// Only the CCN label is updated, the SSN label must not change.
+				Config: repositoryDatamapMappingConfig(
+					[]string{"schema1.table1.col1", "schema1.table1.col2"},
+					[]string{"schema1.table2.col1"},
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cyral_repository_datamap_mapping.ccn", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("cyral_repository_datamap_mapping.ssn", "attributes.#", "1"),
+					resource.TestCheckResourceAttr("cyral_repository_datamap_mapping.ssn",
+						"attributes.0", "schema1.table2.col1"),
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      "cyral_repository_datamap_mapping.ssn",
+			},
+		},
+	})
+}
+
+func repositoryDatamapMappingConfig(ccnAttributes, ssnAttributes []string) string {
+	config := utils.FormatBasicRepositoryIntoConfig(
+		utils.BasicRepositoryResName,
+		utils.AccTestName(repositoryDatamapMappingResourceName, "repo"),
+		"sqlserver",
+		"localhost",
+		1433,
+	)
+	config += fmt.Sprintf(`
+	resource "cyral_repository_datamap_mapping" "ccn" {
+		repository_id = %s
+		label         = "CCN"
+		attributes    = %s
+	}
+
+	resource "cyral_repository_datamap_mapping" "ssn" {
+		repository_id = %s
+		label         = "SSN"
+		attributes    = %s
+	}`, utils.BasicRepositoryID, utils.ListToStr(ccnAttributes),
+		utils.BasicRepositoryID, utils.ListToStr(ssnAttributes))
+	return config
+}
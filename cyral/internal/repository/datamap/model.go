@@ -37,25 +37,25 @@ func (dm *DataMap) WriteToSchema(d *schema.ResourceData) error {
 			attributes = mapping.Attributes
 		}
 
-		mappingContents["label"] = label
-		mappingContents["attributes"] = attributes
+		mappingContents[LabelKey] = label
+		mappingContents[AttributesKey] = attributes
 
 		mappings = append(mappings, mappingContents)
 	}
 	d.SetId(d.Get("repository_id").(string))
 
-	return d.Set("mapping", mappings)
+	return d.Set(MappingKey, mappings)
 }
 
 func (dm *DataMap) ReadFromSchema(d *schema.ResourceData) error {
-	mappings := d.Get("mapping").(*schema.Set).List()
+	mappings := d.Get(MappingKey).(*schema.Set).List()
 	dm.Labels = make(map[string]*DataMapMapping)
 	for _, mappingIface := range mappings {
 		mapping := mappingIface.(map[string]interface{})
 
-		label := mapping["label"].(string)
+		label := mapping[LabelKey].(string)
 		var attributes []string
-		if mappingAtts, ok := mapping["attributes"]; ok {
+		if mappingAtts, ok := mapping[AttributesKey]; ok {
 			for _, attributeIface := range mappingAtts.([]interface{}) {
 				attributes = append(attributes, attributeIface.(string))
 			}
@@ -82,7 +82,7 @@ func (dm *DataMap) equal(other *DataMap) bool {
 }
 
 func getDatamapFromResource(d *schema.ResourceData) DataMap {
-	mappings := d.Get("mapping").(*schema.Set).List()
+	mappings := d.Get(MappingKey).(*schema.Set).List()
 
 	dataMap := DataMap{
 		Labels: make(map[string]*DataMapMapping),
@@ -90,9 +90,9 @@ func getDatamapFromResource(d *schema.ResourceData) DataMap {
 	for _, mappingIface := range mappings {
 		mapping := mappingIface.(map[string]interface{})
 
-		label := mapping["label"].(string)
+		label := mapping[LabelKey].(string)
 		var attributes []string
-		if mappingAtts, ok := mapping["attributes"]; ok {
+		if mappingAtts, ok := mapping[AttributesKey]; ok {
 			for _, attributeIface := range mappingAtts.([]interface{}) {
 				attributes = append(attributes, attributeIface.(string))
 			}
@@ -14,8 +14,12 @@ import (
 
 func resourceSchema() *schema.Resource {
 	return &schema.Resource{
-		Description: "Manages [Data Map](https://cyral.com/docs/policy/datamap).",
-		CreateContext: core.CreateResource(
+		Description: "Manages [Data Map](https://cyral.com/docs/policy/datamap)." +
+			"\n\nThe attributes of each mapping are validated against the format of the data " +
+			"locations of the repository type, for example `{SCHEMA}.{TABLE}.{COLUMN}` for SQL " +
+			"repositories, `{DATABASE}.{COLLECTION}.{FIELD}` for MongoDB and `{BUCKET}/{PREFIX}` " +
+			"for S3.",
+		CreateContext: withDataMapValidation(core.CreateResource(
 			core.ResourceOperationConfig{
 				ResourceName: resourceName,
 				Type:         operationtype.Create,
@@ -28,10 +32,10 @@ func resourceSchema() *schema.Resource {
 				SchemaReaderFactory: func() core.SchemaReader { return &DataMapRequest{} },
 				SchemaWriterFactory: func(_ *schema.ResourceData) core.SchemaWriter { return &DataMap{} },
 			}, readDataMapConfig,
-		),
+		)),
 
 		ReadContext: core.ReadResource(readDataMapConfig),
-		UpdateContext: core.UpdateResource(
+		UpdateContext: withDataMapValidation(core.UpdateResource(
 			core.ResourceOperationConfig{
 				ResourceName: resourceName,
 				Type:         operationtype.Update,
@@ -43,7 +47,7 @@ func resourceSchema() *schema.Resource {
 				},
 				SchemaReaderFactory: func() core.SchemaReader { return &DataMapRequest{} },
 			}, readDataMapConfig,
-		),
+		)),
 		DeleteContext: core.DeleteResource(
 			core.ResourceOperationConfig{
 				ResourceName: resourceName,
@@ -56,6 +60,7 @@ func resourceSchema() *schema.Resource {
 				},
 			},
 		),
+		CustomizeDiff: validateDataMapCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
 				Description: "ID of the repository for which to configure a data map.",
@@ -63,22 +68,29 @@ func resourceSchema() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
-			"mapping": {
+			MappingKey: {
 				Description: "Mapping of a label to a list of data locations (attributes).",
 				Type:        schema.TypeSet,
 				Required:    true,
 				MinItems:    1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"label": {
+						LabelKey: {
 							Description: "Label given to the attributes in this mapping.",
 							Type:        schema.TypeString,
 							Required:    true,
 						},
-						"attributes": {
+						AttributesKey: {
 							Description: "List containing the specific locations of the data within the repo, " +
 								"following the pattern `{SCHEMA}.{TABLE}.{ATTRIBUTE}` (ex: " +
-								"`[your_schema_name.your_table_name.your_attr_name]`).\n\n" +
+								"`[your_schema_name.your_table_name.your_attr_name]`), optionally prefixed by " +
+								"`{DATABASE}.`. The expected pattern depends on the repository type:" +
+								"\n  - `mysql`, `mariadb` and `galera`: `{DATABASE}.{TABLE}.{COLUMN}`." +
+								"\n  - `mongodb`: `{DATABASE}.{COLLECTION}.{FIELD}`, where nested fields are separated by dots." +
+								"\n  - `dynamodb` and `dynamodbstreams`: `{TABLE}.{ATTRIBUTE}`." +
+								"\n  - `s3`: `{BUCKET}/{PREFIX}`, where the prefix is optional." +
+								"\n\nIdentifiers containing dots must be quoted with double quotes, backticks " +
+								"or square brackets.\n\n" +
 								"-> When referencing data in Dremio repository, please include the complete " +
 								"location in `attributes`, separating spaces by dots. For example, an attribute " +
 								"`my_attr` from table `my_tbl` within space `inner_space` within space `outer_space` " +
@@ -2,6 +2,7 @@ package datamap_test
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"testing"
 
@@ -110,6 +111,7 @@ func TestAccRepositoryDatamapResource(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		ProviderFactories: provider.ProviderFactories,
 		Steps: []resource.TestStep{
+			testRepositoryDatamapInvalidAttribute(),
 			testRepositoryDatamapInitialConfigRemoveMapping(t),
 			testRepositoryDatamapUpdatedConfigRemoveMapping(t),
 			testRepositoryDatamapInitialConfigRemoveAttribute(t),
@@ -178,6 +180,25 @@ func testRepositoryDatamapWithDataLabel(t *testing.T) resource.TestStep {
 	return resource.TestStep{Config: config, Check: check}
 }
 
+func testRepositoryDatamapInvalidAttribute() resource.TestStep {
+	resName := "test_invalid_attribute"
+	dataMap := &datamap.DataMap{
+		Labels: map[string]*datamap.DataMapMapping{
+			predefinedLabelCCN: {
+				// Missing the column.
+				Attributes: []string{"schema1.table1"},
+			},
+		},
+	}
+	var config string
+	config += repositoryDatamapSampleRepositoryConfig(resName)
+	config += formatDataMapIntoConfig(resName, utils.BasicRepositoryID, dataMap)
+	return resource.TestStep{
+		Config:      config,
+		ExpectError: regexp.MustCompile(`Invalid attributes in mapping for label "CCN"`),
+	}
+}
+
 func testRepositoryDatamapImport(importStateResName string) resource.TestStep {
 	return resource.TestStep{
 		ImportState:       true,
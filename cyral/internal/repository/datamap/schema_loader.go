@@ -13,6 +13,11 @@ func (p *packageSchema) Name() string {
 
 func (p *packageSchema) Schemas() []*core.SchemaDescriptor {
 	return []*core.SchemaDescriptor{
+		{
+			Name:   dataSourceName,
+			Type:   core.DataSourceSchemaType,
+			Schema: dataSourceSchema,
+		},
 		{
 			Name:   resourceName,
 			Type:   core.ResourceSchemaType,
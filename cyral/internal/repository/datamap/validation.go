@@ -0,0 +1,263 @@
+package datamap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+)
+
+// attributeGrammar describes the format of the attributes of a given
+// repository type, as a range of dot-separated identifiers.
+type attributeGrammar struct {
+	format      string
+	minSegments int
+	// Zero means there is no upper limit.
+	maxSegments int
+}
+
+var (
+	sqlGrammar = attributeGrammar{
+		format:      "[{DATABASE}.]{SCHEMA}.{TABLE}.{COLUMN}",
+		minSegments: 3,
+		maxSegments: 4,
+	}
+	mysqlGrammar = attributeGrammar{
+		format:      "{DATABASE}.{TABLE}.{COLUMN}",
+		minSegments: 3,
+		maxSegments: 3,
+	}
+	dremioGrammar = attributeGrammar{
+		format:      "{SPACE}[.{SPACE}...].{TABLE}.{COLUMN}",
+		minSegments: 3,
+	}
+	mongoDBGrammar = attributeGrammar{
+		format:      "{DATABASE}.{COLLECTION}.{FIELD}[.{FIELD}...]",
+		minSegments: 3,
+	}
+	dynamoDBGrammar = attributeGrammar{
+		format:      "{TABLE}.{ATTRIBUTE}[.{ATTRIBUTE}...]",
+		minSegments: 2,
+	}
+)
+
+var attributeGrammars = map[string]attributeGrammar{
+	repository.Denodo:          sqlGrammar,
+	repository.Oracle:          sqlGrammar,
+	repository.PostgreSQL:      sqlGrammar,
+	repository.Redshift:        sqlGrammar,
+	repository.Snowflake:       sqlGrammar,
+	repository.SQLServer:       sqlGrammar,
+	repository.Galera:          mysqlGrammar,
+	repository.MariaDB:         mysqlGrammar,
+	repository.MySQL:           mysqlGrammar,
+	repository.Dremio:          dremioGrammar,
+	repository.MongoDB:         mongoDBGrammar,
+	repository.DynamoDB:        dynamoDBGrammar,
+	repository.DynamoDBStreams: dynamoDBGrammar,
+}
+
+const s3AttributeFormat = "{BUCKET}[/{PREFIX}]"
+
+var s3BucketRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// ValidateAttribute checks that the attribute follows the format of the
+// data locations of the given repository type. Attributes of unknown
+// repository types are always accepted.
+func ValidateAttribute(repoType, attribute string) error {
+	if repoType == repository.S3 {
+		bucket, _, _ := strings.Cut(attribute, "/")
+		if !s3BucketRegex.MatchString(bucket) || strings.Contains(bucket, "..") {
+			return fmt.Errorf("invalid S3 location %q, expected format `%s` with a valid bucket name",
+				attribute, s3AttributeFormat)
+		}
+		return nil
+	}
+
+	grammar, ok := attributeGrammars[repoType]
+	if !ok {
+		return nil
+	}
+	segments, err := splitAttribute(attribute)
+	if err == nil {
+		switch {
+		case len(segments) < grammar.minSegments:
+			err = fmt.Errorf("expected at least %d dot-separated identifiers, found %d",
+				grammar.minSegments, len(segments))
+		case grammar.maxSegments > 0 && len(segments) > grammar.maxSegments:
+			err = fmt.Errorf("expected at most %d dot-separated identifiers, found %d",
+				grammar.maxSegments, len(segments))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s attribute %q, expected format `%s`: %w",
+			repoType, attribute, grammar.format, err)
+	}
+	return nil
+}
+
+// splitAttribute splits the attribute in its dot-separated identifiers.
+// Dots inside quoted identifiers, delimited by double quotes, backticks
+// or square brackets, are not treated as separators.
+func splitAttribute(attribute string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	var closingQuote rune
+	for _, r := range attribute {
+		switch {
+		case closingQuote != 0:
+			if r == closingQuote {
+				closingQuote = 0
+			}
+		case r == '"' || r == '`':
+			closingQuote = r
+		case r == '[':
+			closingQuote = ']'
+		case r == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if closingQuote != 0 {
+		return nil, fmt.Errorf("unterminated quoted identifier")
+	}
+	segments = append(segments, current.String())
+	for i, segment := range segments {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("identifier %d is empty", i+1)
+		}
+	}
+	return segments, nil
+}
+
+// invalidMappings validates the attributes of all the mappings against
+// the grammar of the given repository type. The errors are keyed by label.
+func (dm *DataMap) invalidMappings(repoType string) map[string][]error {
+	invalid := make(map[string][]error)
+	for label, mapping := range dm.Labels {
+		if mapping == nil {
+			continue
+		}
+		for _, attribute := range mapping.Attributes {
+			if err := ValidateAttribute(repoType, attribute); err != nil {
+				invalid[label] = append(invalid[label], err)
+			}
+		}
+	}
+	return invalid
+}
+
+func sortedLabels(invalid map[string][]error) []string {
+	labels := make([]string, 0, len(invalid))
+	for label := range invalid {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// GetRepositoryType retrieves the type of the given repository, which
+// defines the expected format of the data map attributes.
+func GetRepositoryType(ctx context.Context, c *client.Client, repoID string) (string, error) {
+	url := fmt.Sprintf("https://%s/v1/repos/%s", c.ControlPlane, repoID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	resp := repository.GetRepoByIDResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Repo.Type, nil
+}
+
+// validateDataMapDiagnostics returns one error diagnostic per mapping that
+// contains attributes not matching the grammar of the repository type.
+func validateDataMapDiagnostics(ctx context.Context, d *schema.ResourceData, c *client.Client) diag.Diagnostics {
+	repoID := d.Get("repository_id").(string)
+	repoType, err := GetRepositoryType(ctx, c, repoID)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to retrieve the type of repository %q", repoID),
+			Detail:   err.Error(),
+		}}
+	}
+	dm := getDatamapFromResource(d)
+	invalid := dm.invalidMappings(repoType)
+
+	var diags diag.Diagnostics
+	for _, label := range sortedLabels(invalid) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Invalid attributes in mapping for label %q", label),
+			Detail:   errors.Join(invalid[label]...).Error(),
+		})
+	}
+	return diags
+}
+
+// withDataMapValidation validates the mappings before calling the given
+// create or update function.
+func withDataMapValidation(
+	f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if diags := validateDataMapDiagnostics(ctx, d, m.(*client.Client)); diags.HasError() {
+			return diags
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// validateDataMapCustomizeDiff reports invalid attributes at plan time when
+// the repository already exists. Otherwise, they are reported when the
+// data map is created.
+func validateDataMapCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("repository_id") || !d.NewValueKnown(MappingKey) {
+		return nil
+	}
+	repoID := d.Get("repository_id").(string)
+	repoType, err := GetRepositoryType(ctx, m.(*client.Client), repoID)
+	if err != nil {
+		// The repository may have been deleted outside of Terraform, in
+		// which case it is not possible to validate the mappings.
+		tflog.Debug(ctx, fmt.Sprintf("Unable to retrieve the type of repository %q: %v", repoID, err))
+		return nil
+	}
+
+	dm := DataMap{Labels: make(map[string]*DataMapMapping)}
+	for _, mappingIface := range d.Get(MappingKey).(*schema.Set).List() {
+		mapping := mappingIface.(map[string]interface{})
+		var attributes []string
+		for _, attributeIface := range mapping[AttributesKey].([]interface{}) {
+			// Unknown attributes are read as empty strings and are only
+			// validated when the data map is created or updated.
+			if attribute, _ := attributeIface.(string); attribute != "" {
+				attributes = append(attributes, attribute)
+			}
+		}
+		dm.Labels[mapping[LabelKey].(string)] = &DataMapMapping{Attributes: attributes}
+	}
+
+	invalid := dm.invalidMappings(repoType)
+	var errs []error
+	for _, label := range sortedLabels(invalid) {
+		errs = append(errs, fmt.Errorf("invalid attributes in mapping for label %q: %w",
+			label, errors.Join(invalid[label]...)))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,198 @@
+package datamap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+)
+
+func TestValidateAttribute(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		repoType    string
+		attribute   string
+		expectError bool
+	}{
+		{
+			desc:      "sql with schema, table and column",
+			repoType:  repository.PostgreSQL,
+			attribute: "schema.table.column",
+		},
+		{
+			desc:      "sql with database, schema, table and column",
+			repoType:  repository.SQLServer,
+			attribute: "database.schema.table.column",
+		},
+		{
+			desc:        "sql with too few identifiers",
+			repoType:    repository.Snowflake,
+			attribute:   "table.column",
+			expectError: true,
+		},
+		{
+			desc:        "sql with too many identifiers",
+			repoType:    repository.Oracle,
+			attribute:   "a.b.c.d.e",
+			expectError: true,
+		},
+		{
+			desc:      "mysql with database, table and column",
+			repoType:  repository.MySQL,
+			attribute: "database.table.column",
+		},
+		{
+			desc:        "mysql with a schema",
+			repoType:    repository.MariaDB,
+			attribute:   "database.schema.table.column",
+			expectError: true,
+		},
+		{
+			desc:      "dremio with nested spaces",
+			repoType:  repository.Dremio,
+			attribute: "space.subspace.table.column",
+		},
+		{
+			desc:        "dremio with too few identifiers",
+			repoType:    repository.Dremio,
+			attribute:   "table.column",
+			expectError: true,
+		},
+		{
+			desc:      "mongodb with nested fields",
+			repoType:  repository.MongoDB,
+			attribute: "database.collection.field.subfield",
+		},
+		{
+			desc:        "mongodb without a field",
+			repoType:    repository.MongoDB,
+			attribute:   "database.collection",
+			expectError: true,
+		},
+		{
+			desc:      "dynamodb with table and attribute",
+			repoType:  repository.DynamoDB,
+			attribute: "table.attribute",
+		},
+		{
+			desc:        "dynamodb without an attribute",
+			repoType:    repository.DynamoDBStreams,
+			attribute:   "table",
+			expectError: true,
+		},
+		{
+			desc:      "quoted identifiers with dots",
+			repoType:  repository.PostgreSQL,
+			attribute: `"my.schema".[my.table].column`,
+		},
+		{
+			desc:        "empty identifier",
+			repoType:    repository.PostgreSQL,
+			attribute:   "schema..column",
+			expectError: true,
+		},
+		{
+			desc:      "s3 bucket",
+			repoType:  repository.S3,
+			attribute: "my-bucket",
+		},
+		{
+			desc:      "s3 bucket with prefix",
+			repoType:  repository.S3,
+			attribute: "my.bucket/path/to/data",
+		},
+		{
+			desc:        "s3 bucket with upper case letters",
+			repoType:    repository.S3,
+			attribute:   "My-Bucket/path",
+			expectError: true,
+		},
+		{
+			desc:        "s3 bucket with consecutive dots",
+			repoType:    repository.S3,
+			attribute:   "my..bucket",
+			expectError: true,
+		},
+		{
+			desc:        "s3 bucket too short",
+			repoType:    repository.S3,
+			attribute:   "ab",
+			expectError: true,
+		},
+		{
+			desc:      "unknown repository type",
+			repoType:  "unknown",
+			attribute: "anything",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			err := ValidateAttribute(testCase.repoType, testCase.attribute)
+			if testCase.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSplitAttribute(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		attribute   string
+		expected    []string
+		expectError bool
+	}{
+		{
+			desc:      "unquoted identifiers",
+			attribute: "schema.table.column",
+			expected:  []string{"schema", "table", "column"},
+		},
+		{
+			desc:      "double quoted identifier",
+			attribute: `"my.schema".table`,
+			expected:  []string{`"my.schema"`, "table"},
+		},
+		{
+			desc:      "backtick quoted identifier",
+			attribute: "database.`my.table`",
+			expected:  []string{"database", "`my.table`"},
+		},
+		{
+			desc:      "square bracket quoted identifier",
+			attribute: "[my.database].table",
+			expected:  []string{"[my.database]", "table"},
+		},
+		{
+			desc:        "unterminated quoted identifier",
+			attribute:   `schema."table.column`,
+			expectError: true,
+		},
+		{
+			desc:        "trailing dot",
+			attribute:   "schema.table.",
+			expectError: true,
+		},
+		{
+			desc:        "blank identifier",
+			attribute:   "schema. .column",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			segments, err := splitAttribute(testCase.attribute)
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, segments)
+		})
+	}
+}
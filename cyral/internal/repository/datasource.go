@@ -1,22 +1,32 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
-	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
-	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/resourcetype"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 const (
-	RepoListKey = "repository_list"
+	RepoListKey        = "repository_list"
+	RepoLabelsMatchKey = "labels_match"
 )
 
+// Page size used when listing repositories.
+const listReposPageSize = 100
+
 // GetReposSubResponse is different from GetRepoByIDResponse. For the by-id
 // response, we expect the ids to be embedded in the RepoInfo struct. For
 // GetReposSubResponse, the ids come outside of RepoInfo.
@@ -34,22 +44,15 @@ type GetReposResponse struct {
 }
 
 func (resp *GetReposResponse) WriteToSchema(d *schema.ResourceData) error {
-	var repoList []interface{}
+	repoList := make([]interface{}, 0, len(resp.Repos))
 	for _, repo := range resp.Repos {
-		argumentVals := map[string]interface{}{
-			RepoIDKey:              repo.ID,
-			RepoNameKey:            repo.Repo.Name,
-			RepoTypeKey:            repo.Repo.Type,
-			RepoLabelsKey:          repo.Repo.Labels.AsInterface(),
-			RepoConnDrainingKey:    repo.Repo.ConnParams.AsInterface(),
-			RepoNodesKey:           repo.Repo.RepoNodes.AsInterface(),
-			RepoMongoDBSettingsKey: repo.Repo.MongoDBSettings.AsInterface(),
-		}
+		argumentVals := repo.Repo.AsMap()
+		argumentVals[RepoIDKey] = repo.ID
 		repoList = append(repoList, argumentVals)
 	}
 
-	if err := d.Set("repository_list", repoList); err != nil {
-		return err
+	if err := d.Set(RepoListKey, repoList); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, RepoListKey, err)
 	}
 
 	d.SetId(uuid.New().String())
@@ -57,26 +60,132 @@ func (resp *GetReposResponse) WriteToSchema(d *schema.ResourceData) error {
 	return nil
 }
 
-var dsContextHandler = core.HTTPContextHandler{
-	ResourceName:                 dataSourceName,
-	ResourceType:                 resourcetype.DataSource,
-	SchemaWriterFactoryGetMethod: func(_ *schema.ResourceData) core.SchemaWriter { return &GetReposResponse{} },
-	ReadUpdateDeleteURLFactory: func(d *schema.ResourceData, c *client.Client) string {
-		nameFilter := d.Get("name").(string)
-		typeFilter := d.Get("type").(string)
-		urlParams := utils.UrlQuery(map[string]string{
-			"name": nameFilter,
-			"type": typeFilter,
-		})
-
-		return fmt.Sprintf("https://%s/v1/repos%s", c.ControlPlane, urlParams)
-	},
+// ListRepositories retrieves all the repositories existing in the control
+// plane that match the given name regex and type, fetching all the pages
+// of results. Empty filters are ignored.
+func ListRepositories(ctx context.Context, c *client.Client, nameFilter, typeFilter string) ([]GetReposSubResponse, error) {
+	tflog.Debug(ctx, "Init ListRepositories")
+	var repos []GetReposSubResponse
+	seenIDs := make(map[string]bool)
+	pageAfter := ""
+	for {
+		query := url.Values{}
+		query.Set("pageSize", strconv.Itoa(listReposPageSize))
+		if pageAfter != "" {
+			query.Set("pageAfter", pageAfter)
+		}
+		if nameFilter != "" {
+			query.Set("name", nameFilter)
+		}
+		if typeFilter != "" {
+			query.Set("type", typeFilter)
+		}
+		reqURL := fmt.Sprintf("https://%s/v1/repos?%s", c.ControlPlane, query.Encode())
+		body, err := c.DoRequest(ctx, reqURL, http.MethodGet, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp GetReposResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", resp))
+
+		newRepos := 0
+		for _, repo := range resp.Repos {
+			// Protects against control planes that ignore the pagination
+			// parameters and return the same page again.
+			if seenIDs[repo.ID] {
+				continue
+			}
+			seenIDs[repo.ID] = true
+			repos = append(repos, repo)
+			newRepos++
+		}
+		if newRepos == 0 || len(resp.Repos) < listReposPageSize {
+			break
+		}
+		pageAfter = resp.Repos[len(resp.Repos)-1].ID
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Found %d repositories", len(repos)))
+	tflog.Debug(ctx, "End ListRepositories")
+
+	return repos, nil
+}
+
+// repoFilter holds the filters of the repository data source that are
+// applied locally, as they are not supported by the API.
+type repoFilter struct {
+	labels      []string
+	labelsMatch string
+	hostRegex   *regexp.Regexp
+}
+
+func (f *repoFilter) matches(repo *RepoInfo) bool {
+	if !utils.MatchLabels(repo.Labels, f.labels, f.labelsMatch) {
+		return false
+	}
+	if f.hostRegex != nil {
+		hostMatched := repo.Host != "" && f.hostRegex.MatchString(repo.Host)
+		for _, node := range repo.RepoNodes {
+			if node.Host != "" && f.hostRegex.MatchString(node.Host) {
+				hostMatched = true
+			}
+		}
+		if !hostMatched {
+			return false
+		}
+	}
+	return true
+}
+
+func dataSourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceRepositoryRead")
+	c := m.(*client.Client)
+
+	filter := &repoFilter{
+		labels:      utils.GetStrListFromSchemaField(d, RepoLabelsKey),
+		labelsMatch: d.Get(RepoLabelsMatchKey).(string),
+	}
+	if hostFilter := d.Get(RepoHostKey).(string); hostFilter != "" {
+		hostRegex, err := regexp.Compile(hostFilter)
+		if err != nil {
+			return utils.CreateError("Invalid host filter", err.Error())
+		}
+		filter.hostRegex = hostRegex
+	}
+
+	repos, err := ListRepositories(ctx, c, d.Get(RepoNameKey).(string), d.Get(RepoTypeKey).(string))
+	if err != nil {
+		return utils.CreateError("Unable to retrieve the list of repositories", err.Error())
+	}
+
+	resp := &GetReposResponse{}
+	for _, repo := range repos {
+		if filter.matches(&repo.Repo) {
+			resp.Repos = append(resp.Repos, repo)
+		}
+	}
+	if err := resp.WriteToSchema(d); err != nil {
+		return utils.CreateError("Unable to read repositories", err.Error())
+	}
+
+	tflog.Debug(ctx, "End dataSourceRepositoryRead")
+	return nil
 }
 
 func dataSourceSchema() *schema.Resource {
+	repoListSchema := utils.ConvertSchemaFieldsToComputed(RepoInfoSchema())
+	repoListSchema[RepoIDKey] = &schema.Schema{
+		Description: "ID of the repository in the Cyral environment.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
 	return &schema.Resource{
 		Description: "Retrieves a list of repositories. See [`repository_list`](#nestedatt--repository_list).",
-		ReadContext: dsContextHandler.ReadContext(),
+		ReadContext: dataSourceRepositoryRead,
 		Schema: map[string]*schema.Schema{
 			RepoNameKey: {
 				Description: "Filter the results by a regular expression (regex) that matches names of existing repositories.",
@@ -89,114 +198,38 @@ func dataSourceSchema() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringInSlice(append(RepositoryTypes(), ""), false),
 			},
+			RepoLabelsKey: {
+				Description: "Filter the results by repository labels. See [`" + RepoLabelsMatchKey + "`](#" +
+					RepoLabelsMatchKey + ") for how multiple labels are combined.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			RepoLabelsMatchKey: {
+				Description: "Defines how the `" + RepoLabelsKey + "` filter is applied. If `" + utils.LabelsMatchAll +
+					"`, only repositories that have all the given labels are returned. If `" + utils.LabelsMatchAny +
+					"`, repositories that have at least one of the given labels are returned. Defaults to `" +
+					utils.LabelsMatchAll + "`. List of supported values:" + utils.SupportedValuesAsMarkdown(utils.LabelsMatchModes()),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      utils.LabelsMatchAll,
+				ValidateFunc: validation.StringInSlice(utils.LabelsMatchModes(), false),
+			},
+			RepoHostKey: {
+				Description: "Filter the results by a regular expression (regex) that matches the host of " +
+					"at least one of the repository nodes.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
 			RepoListKey: {
 				Description: "List of existing repositories satisfying the filter criteria.",
 				Computed:    true,
 				Type:        schema.TypeList,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						RepoIDKey: {
-							Description: "ID of the repository in the Cyral environment.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						RepoNameKey: {
-							Description: "Repository name.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						RepoTypeKey: {
-							Description: "Repository type.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						RepoLabelsKey: {
-							Description: "Repository labels.",
-							Type:        schema.TypeList,
-							Computed:    true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						RepoConnDrainingKey: {
-							Description: "Parameters related to connection draining.",
-							Type:        schema.TypeSet,
-							Computed:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									RepoConnDrainingAutoKey: {
-										Description: "Whether connections should be drained automatically after a listener dies.",
-										Type:        schema.TypeBool,
-										Computed:    true,
-									},
-									RepoConnDrainingWaitTimeKey: {
-										Description: "Seconds to wait to let connections drain before starting to kill all the connections, " +
-											"if auto is set to true.",
-										Type:     schema.TypeInt,
-										Computed: true,
-									},
-								},
-							},
-						},
-						RepoNodesKey: {
-							Description: "List of nodes for this repository.",
-							Type:        schema.TypeList,
-							Computed:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									RepoNameKey: {
-										Description: "Name of the repo node.",
-										Type:        schema.TypeString,
-										Computed:    true,
-									},
-									RepoHostKey: {
-										Description: "Repo node host (ex: `somerepo.cyral.com`). Can be empty if node is dynamic.",
-										Type:        schema.TypeString,
-										Computed:    true,
-									},
-									RepoPortKey: {
-										Description: "Repository access port (ex: `3306`). Can be empty if node is dynamic.",
-										Type:        schema.TypeInt,
-										Computed:    true,
-									},
-									RepoNodeDynamicKey: {
-										Description: "Indicates if node is dynamically discovered. If true, `host` and `port` must be empty.",
-										Type:        schema.TypeBool,
-										Computed:    true,
-									},
-								},
-							},
-						},
-						RepoMongoDBSettingsKey: {
-							Description: "Parameters related to MongoDB repositories.",
-							Type:        schema.TypeSet,
-							Computed:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									RepoMongoDBReplicaSetNameKey: {
-										Description: "Name of the replica set, if applicable.",
-										Type:        schema.TypeString,
-										Computed:    true,
-									},
-									RepoMongoDBServerTypeKey: {
-										Description: "Type of the MongoDB server.",
-										Type:        schema.TypeString,
-										Computed:    true,
-									},
-									RepoMongoDBSRVRecordName: {
-										Description: "Name of a DNS SRV record which contains cluster topology details.",
-										Type:        schema.TypeString,
-										Optional:    true,
-									},
-									RepoMongoDBFlavorKey: {
-										Description: "The flavor of the MongoDB deployment.",
-										Type:        schema.TypeString,
-										Optional:    true,
-									},
-								},
-							},
-						},
-					},
+					Schema: repoListSchema,
 				},
 			},
 		},
@@ -193,3 +193,62 @@ func repositoryDataSourceConfig(nameFilter, typeFilter string, dependsOn []strin
 		type = "%s"
 	}`, utils.ListToStr(dependsOn), nameFilter, typeFilter)
 }
+
+func TestAccRepositoryDataSourceLabelsAndHostFilters(t *testing.T) {
+	// Uses different names from TestAccRepositoryDataSource, as both tests
+	// run in parallel.
+	namePrefix := utils.AccTestName(repositoryDataSourceName, "filters")
+	testRepos := repositoryDataSourceTestRepos()
+	testRepos[0].Name = namePrefix + "-sqlserver-1"
+	testRepos[1].Name = namePrefix + "-mongodb-1"
+	var reposConfig string
+	var dependsOn []string
+	for _, repoData := range testRepos {
+		reposConfig += repoAsConfig(repoData, repoData.Name)
+		dependsOn = append(dependsOn, fmt.Sprintf("cyral_repository.%s", repoData.Name))
+	}
+	dataSourceFullName := "data.cyral_repository.test_repository"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Only the SQL Server repository has both labels.
+				Config: reposConfig + fmt.Sprintf(`
+				data "cyral_repository" "test_repository" {
+					depends_on = %s
+					name       = "^%s"
+					labels     = ["rds", "us-east-2"]
+				}`, utils.ListToStr(dependsOn), namePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "repository_list.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "repository_list.0.name", testRepos[0].Name),
+				),
+			},
+			{
+				Config: reposConfig + fmt.Sprintf(`
+				data "cyral_repository" "test_repository" {
+					depends_on   = %s
+					name         = "^%s"
+					labels       = ["us-east-1", "us-east-2"]
+					labels_match = "any"
+				}`, utils.ListToStr(dependsOn), namePrefix),
+				Check: resource.TestCheckResourceAttr(dataSourceFullName, "repository_list.#", "2"),
+			},
+			{
+				Config: reposConfig + fmt.Sprintf(`
+				data "cyral_repository" "test_repository" {
+					depends_on = %s
+					name       = "^%s"
+					host       = "^mongo\\."
+				}`, utils.ListToStr(dependsOn), namePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "repository_list.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "repository_list.0.name", testRepos[1].Name),
+					resource.TestCheckResourceAttr(dataSourceFullName,
+						"repository_list.0.mongodb_settings.0.server_type", repository.Standalone),
+				),
+			},
+		},
+	})
+}
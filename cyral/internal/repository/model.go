@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -11,16 +12,22 @@ type Labels []string
 type RepoNodes []*RepoNode
 
 type RepoInfo struct {
-	ID               string            `json:"id"`
-	Name             string            `json:"name"`
-	Type             string            `json:"type"`
-	Host             string            `json:"repoHost"`
-	Port             uint32            `json:"repoPort"`
-	ConnParams       *ConnParams       `json:"connParams"`
-	Labels           Labels            `json:"labels"`
-	RepoNodes        RepoNodes         `json:"repoNodes,omitempty"`
-	MongoDBSettings  *MongoDBSettings  `json:"mongoDbSettings,omitempty"`
-	RedshiftSettings *RedshiftSettings `json:"redshiftSettings,omitempty"`
+	ID                 string              `json:"id"`
+	Name               string              `json:"name"`
+	Type               string              `json:"type"`
+	Host               string              `json:"repoHost"`
+	Port               uint32              `json:"repoPort"`
+	ConnParams         *ConnParams         `json:"connParams"`
+	Labels             Labels              `json:"labels"`
+	RepoNodes          RepoNodes           `json:"repoNodes,omitempty"`
+	MongoDBSettings    *MongoDBSettings    `json:"mongoDbSettings,omitempty"`
+	RedshiftSettings   *RedshiftSettings   `json:"redshiftSettings,omitempty"`
+	OracleSettings     *OracleSettings     `json:"oracleSettings,omitempty"`
+	SnowflakeSettings  *SnowflakeSettings  `json:"snowflakeSettings,omitempty"`
+	SQLServerSettings  *SQLServerSettings  `json:"sqlServerSettings,omitempty"`
+	PostgreSQLSettings *PostgreSQLSettings `json:"postgresqlSettings,omitempty"`
+	S3Settings         *AWSServiceSettings `json:"s3Settings,omitempty"`
+	DynamoDBSettings   *AWSServiceSettings `json:"dynamoDbSettings,omitempty"`
 }
 
 type ConnParams struct {
@@ -45,6 +52,33 @@ type RedshiftSettings struct {
 	AWSRegion         string `json:"awsRegion,omitempty"`
 }
 
+type OracleSettings struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	SID         string `json:"sid,omitempty"`
+}
+
+type SnowflakeSettings struct {
+	Account   string `json:"account,omitempty"`
+	Warehouse string `json:"warehouse,omitempty"`
+}
+
+type SQLServerSettings struct {
+	InstanceName string `json:"instanceName,omitempty"`
+}
+
+type PostgreSQLSettings struct {
+	SSLMode           string `json:"sslMode,omitempty"`
+	ClusterIdentifier string `json:"clusterIdentifier,omitempty"`
+	AWSRegion         string `json:"awsRegion,omitempty"`
+}
+
+// AWSServiceSettings holds the settings of repositories that are AWS
+// services, such as S3 and DynamoDB.
+type AWSServiceSettings struct {
+	AWSRegion string `json:"awsRegion,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+}
+
 type RepoNode struct {
 	Name    string `json:"name"`
 	Host    string `json:"host"`
@@ -61,43 +95,127 @@ func (res *GetRepoByIDResponse) WriteToSchema(d *schema.ResourceData) error {
 }
 
 func (res *RepoInfo) WriteToSchema(d *schema.ResourceData) error {
-	d.Set(RepoTypeKey, res.Type)
-	d.Set(RepoNameKey, res.Name)
-	d.Set(RepoLabelsKey, res.Labels.AsInterface())
-	d.Set(RepoConnDrainingKey, res.ConnParams.AsInterface())
-	d.Set(RepoNodesKey, res.RepoNodes.AsInterface())
-	d.Set(RepoMongoDBSettingsKey, res.MongoDBSettings.AsInterface())
-	d.Set(RepoRedshiftSettingsKey, res.RedshiftSettings.AsInterface())
+	for key, value := range res.AsMap() {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf(utils.ErrorSettingFieldFmt, key, err)
+		}
+	}
 	return nil
 }
 
+// AsMap returns the repository attributes keyed by their schema keys. The
+// repository ID is not included, as it is stored in different places
+// depending on the resource or data source using it.
+func (res *RepoInfo) AsMap() map[string]interface{} {
+	return map[string]interface{}{
+		RepoTypeKey:               res.Type,
+		RepoNameKey:               res.Name,
+		RepoLabelsKey:             res.Labels.AsInterface(),
+		RepoConnDrainingKey:       res.ConnParams.AsInterface(),
+		RepoNodesKey:              res.RepoNodes.AsInterface(),
+		RepoMongoDBSettingsKey:    res.MongoDBSettings.AsInterface(),
+		RepoRedshiftSettingsKey:   res.RedshiftSettings.AsInterface(),
+		RepoOracleSettingsKey:     res.OracleSettings.AsInterface(),
+		RepoSnowflakeSettingsKey:  res.SnowflakeSettings.AsInterface(),
+		RepoSQLServerSettingsKey:  res.SQLServerSettings.AsInterface(),
+		RepoPostgreSQLSettingsKey: res.PostgreSQLSettings.AsInterface(),
+		RepoS3SettingsKey:         res.S3Settings.AsInterface(),
+		RepoDynamoDBSettingsKey:   res.DynamoDBSettings.AsInterface(),
+	}
+}
+
 func (r *RepoInfo) ReadFromSchema(d *schema.ResourceData) error {
 	r.ID = d.Id()
-	r.Name = d.Get(RepoNameKey).(string)
-	r.Type = d.Get(RepoTypeKey).(string)
-	r.Labels = labelsFromInterface(d.Get(RepoLabelsKey).([]interface{}))
-	r.RepoNodes = repoNodesFromInterface(d.Get(RepoNodesKey).([]interface{}))
-	r.ConnParams = connDrainingFromInterface(d.Get(RepoConnDrainingKey).(*schema.Set).List())
-	var mongoDBSettings = d.Get(RepoMongoDBSettingsKey).(*schema.Set).List()
+	m := make(map[string]interface{})
+	for key := range RepoInfoSchema() {
+		m[key] = d.Get(key)
+	}
+	return r.ReadFromMap(m)
+}
+
+// ReadFromMap reads the repository attributes from a map keyed by the
+// schema keys returned by RepoInfoSchema, such as the ones found in
+// nested repository blocks.
+func (r *RepoInfo) ReadFromMap(m map[string]interface{}) error {
+	r.Name = m[RepoNameKey].(string)
+	r.Type = m[RepoTypeKey].(string)
+	r.Labels = labelsFromInterface(m[RepoLabelsKey].([]interface{}))
+	r.RepoNodes = repoNodesFromInterface(m[RepoNodesKey].([]interface{}))
+	r.ConnParams = connDrainingFromInterface(m[RepoConnDrainingKey].(*schema.Set).List())
+	var mongoDBSettings = m[RepoMongoDBSettingsKey].(*schema.Set).List()
 	if r.Type == MongoDB && len(mongoDBSettings) == 0 {
 		return fmt.Errorf("'%s' block must be provided when '%s=%s'", RepoMongoDBSettingsKey, utils.TypeKey, MongoDB)
 	} else if r.Type != MongoDB && len(mongoDBSettings) > 0 {
 		return fmt.Errorf("'%s' block is only allowed when '%s=%s'", RepoMongoDBSettingsKey, utils.TypeKey, MongoDB)
 	}
-	m, err := mongoDBSettingsFromInterface(mongoDBSettings)
+	mongo, err := mongoDBSettingsFromInterface(mongoDBSettings)
 	if err != nil {
 		return err
 	}
-	r.MongoDBSettings = m
+	r.MongoDBSettings = mongo
 
-	var redshiftSettings = d.Get(RepoRedshiftSettingsKey).(*schema.Set).List()
-	if r.Type != Redshift && len(redshiftSettings) > 0 {
-		return fmt.Errorf("'%s' block is only allowed when '%s=%s'", RepoRedshiftSettingsKey, utils.TypeKey, Redshift)
+	var redshiftSettings = m[RepoRedshiftSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoRedshiftSettingsKey, redshiftSettings, r.Type, Redshift); err != nil {
+		return err
 	}
 	redshift, err := redshiftSettingsFromInterface(redshiftSettings)
+	if err != nil {
+		return err
+	}
 	r.RedshiftSettings = redshift
 
-	return err
+	var oracleSettings = m[RepoOracleSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoOracleSettingsKey, oracleSettings, r.Type, Oracle); err != nil {
+		return err
+	}
+	if r.OracleSettings, err = oracleSettingsFromInterface(oracleSettings); err != nil {
+		return err
+	}
+
+	var snowflakeSettings = m[RepoSnowflakeSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoSnowflakeSettingsKey, snowflakeSettings, r.Type, Snowflake); err != nil {
+		return err
+	}
+	r.SnowflakeSettings = snowflakeSettingsFromInterface(snowflakeSettings)
+
+	var sqlServerSettings = m[RepoSQLServerSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoSQLServerSettingsKey, sqlServerSettings, r.Type, SQLServer); err != nil {
+		return err
+	}
+	r.SQLServerSettings = sqlServerSettingsFromInterface(sqlServerSettings)
+
+	var postgreSQLSettings = m[RepoPostgreSQLSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoPostgreSQLSettingsKey, postgreSQLSettings, r.Type, PostgreSQL); err != nil {
+		return err
+	}
+	r.PostgreSQLSettings = postgreSQLSettingsFromInterface(postgreSQLSettings)
+
+	var s3Settings = m[RepoS3SettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoS3SettingsKey, s3Settings, r.Type, S3); err != nil {
+		return err
+	}
+	r.S3Settings = awsServiceSettingsFromInterface(s3Settings)
+
+	var dynamoDBSettings = m[RepoDynamoDBSettingsKey].(*schema.Set).List()
+	if err := checkSettingsAllowed(RepoDynamoDBSettingsKey, dynamoDBSettings, r.Type, dynamoDBTypes()...); err != nil {
+		return err
+	}
+	r.DynamoDBSettings = awsServiceSettingsFromInterface(dynamoDBSettings)
+
+	return nil
+}
+
+// checkSettingsAllowed returns an error if a settings block was provided for
+// a repository whose type is not one of allowedTypes.
+func checkSettingsAllowed(settingsKey string, settings []interface{}, repoType string, allowedTypes ...string) error {
+	if len(settings) == 0 || slices.Contains(allowedTypes, repoType) {
+		return nil
+	}
+	if len(allowedTypes) == 1 {
+		return fmt.Errorf("'%s' block is only allowed when '%s=%s'", settingsKey, utils.TypeKey, allowedTypes[0])
+	}
+	return fmt.Errorf("'%s' block is only allowed when '%s' is one of %s", settingsKey, utils.TypeKey,
+		utils.ListToStr(allowedTypes))
 }
 
 func (l *Labels) AsInterface() []interface{} {
@@ -246,3 +364,114 @@ func mongoDBSettingsFromInterface(i []interface{}) (*MongoDBSettings, error) {
 		Flavor:         i[0].(map[string]interface{})[RepoMongoDBFlavorKey].(string),
 	}, nil
 }
+
+func (o *OracleSettings) AsInterface() []interface{} {
+	if o == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		RepoOracleServiceNameKey: o.ServiceName,
+		RepoOracleSIDKey:         o.SID,
+	}}
+}
+
+func oracleSettingsFromInterface(i []interface{}) (*OracleSettings, error) {
+	if len(i) == 0 {
+		return nil, nil
+	}
+	var serviceName = i[0].(map[string]interface{})[RepoOracleServiceNameKey].(string)
+	var sid = i[0].(map[string]interface{})[RepoOracleSIDKey].(string)
+	if (serviceName == "") == (sid == "") {
+		return nil, fmt.Errorf("exactly one of '%s' or '%s' must be provided in '%s'",
+			RepoOracleServiceNameKey, RepoOracleSIDKey, RepoOracleSettingsKey)
+	}
+	return &OracleSettings{
+		ServiceName: serviceName,
+		SID:         sid,
+	}, nil
+}
+
+func (s *SnowflakeSettings) AsInterface() []interface{} {
+	if s == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		RepoSnowflakeAccountKey:   s.Account,
+		RepoSnowflakeWarehouseKey: s.Warehouse,
+	}}
+}
+
+func snowflakeSettingsFromInterface(i []interface{}) *SnowflakeSettings {
+	if len(i) == 0 {
+		return nil
+	}
+	return &SnowflakeSettings{
+		Account:   i[0].(map[string]interface{})[RepoSnowflakeAccountKey].(string),
+		Warehouse: i[0].(map[string]interface{})[RepoSnowflakeWarehouseKey].(string),
+	}
+}
+
+func (s *SQLServerSettings) AsInterface() []interface{} {
+	if s == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		RepoSQLServerInstanceNameKey: s.InstanceName,
+	}}
+}
+
+func sqlServerSettingsFromInterface(i []interface{}) *SQLServerSettings {
+	if len(i) == 0 {
+		return nil
+	}
+	return &SQLServerSettings{
+		InstanceName: i[0].(map[string]interface{})[RepoSQLServerInstanceNameKey].(string),
+	}
+}
+
+func (p *PostgreSQLSettings) AsInterface() []interface{} {
+	if p == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		RepoPostgreSQLSSLModeKey:           p.SSLMode,
+		RepoPostgreSQLClusterIdentifierKey: p.ClusterIdentifier,
+		RepoPostgreSQLAWSRegionKey:         p.AWSRegion,
+	}}
+}
+
+func postgreSQLSettingsFromInterface(i []interface{}) *PostgreSQLSettings {
+	if len(i) == 0 {
+		return nil
+	}
+	return &PostgreSQLSettings{
+		SSLMode:           i[0].(map[string]interface{})[RepoPostgreSQLSSLModeKey].(string),
+		ClusterIdentifier: i[0].(map[string]interface{})[RepoPostgreSQLClusterIdentifierKey].(string),
+		AWSRegion:         i[0].(map[string]interface{})[RepoPostgreSQLAWSRegionKey].(string),
+	}
+}
+
+func (a *AWSServiceSettings) AsInterface() []interface{} {
+	if a == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		RepoAWSRegionKey:   a.AWSRegion,
+		RepoAWSEndpointKey: a.Endpoint,
+	}}
+}
+
+func awsServiceSettingsFromInterface(i []interface{}) *AWSServiceSettings {
+	if len(i) == 0 {
+		return nil
+	}
+	return &AWSServiceSettings{
+		AWSRegion: i[0].(map[string]interface{})[RepoAWSRegionKey].(string),
+		Endpoint:  i[0].(map[string]interface{})[RepoAWSEndpointKey].(string),
+	}
+}
@@ -3,7 +3,11 @@ package network
 import "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
 
 const (
-	resourceName = "cyral_repository_network_access_policy"
+	resourceName   = "cyral_repository_network_access_policy"
+	dataSourceName = "cyral_network_access_evaluation"
+
+	decisionAllow = "allow"
+	decisionDeny  = "deny"
 
 	defaultNetworkAccessPolicyEnabled    = true
 	defaultNetworkAccessRulesBlockAccess = false
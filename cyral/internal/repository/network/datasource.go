@@ -0,0 +1,212 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Evaluates whether a connection would be allowed by the current network access policy of " +
+			"a repository, which allows testing a policy before rolling it out. See " +
+			"[`cyral_repository_network_access_policy`](../resources/repository_network_access_policy.md)." +
+			"\n\nThe evaluation is computed locally, following the same rules as the sidecar:" +
+			"\n  - Connections are allowed if the repository has no network access policy, if the policy is " +
+			"disabled or if it has no rules." +
+			"\n  - A rule matches a connection if the database account is one of the rule `db_accounts` and the " +
+			"source IP is in one of the rule `source_ips`. Rules without `db_accounts` or `source_ips` match any " +
+			"account or address, respectively." +
+			"\n  - If `network_access_rules_block_access` is `true`, connections that match some rule are denied " +
+			"and the other ones are allowed. Otherwise, only connections that match some rule are allowed.",
+		ReadContext: dataSourceNetworkAccessEvaluationRead,
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Description: "ID of the repository whose network access policy is evaluated.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"source_ip": {
+				Description:  "IPv4 or IPv6 address of the client connecting to the repository.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSourceAddress,
+			},
+			"db_account": {
+				Description: "Database account used by the client to connect to the repository.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"enabled": {
+				Description: "Whether the network access policy of the repository is enabled.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"rules_block_access": {
+				Description: "Whether the rules of the network access policy block the connections that match " +
+					"them, instead of allowing them.",
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"decision": {
+				Description: "Result of the evaluation: `" + decisionAllow + "` or `" + decisionDeny + "`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"allowed": {
+				Description: "Whether the connection is allowed, i.e. `decision` is `" + decisionAllow + "`.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"matching_rule": {
+				Description: "Name of the first rule of the policy that matches the connection. Empty if no rule " +
+					"matches it.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"reason": {
+				Description: "Human-readable explanation of the decision.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNetworkAccessEvaluationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceNetworkAccessEvaluationRead")
+	c := m.(*client.Client)
+	repoID := d.Get("repository_id").(string)
+	sourceIP := d.Get("source_ip").(string)
+	dbAccount := d.Get("db_account").(string)
+
+	addr, err := netip.ParseAddr(sourceIP)
+	if err != nil {
+		return utils.CreateError("Invalid source IP", err.Error())
+	}
+
+	nap, err := getNetworkAccessPolicy(ctx, c, repoID)
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read network access policy of repository %q", repoID),
+			err.Error())
+	}
+	evaluation := nap.evaluate(addr.Unmap(), dbAccount)
+
+	fields := map[string]interface{}{
+		"enabled":            nap.Enabled,
+		"rules_block_access": nap.NetworkAccessRules.RulesBlockAccess,
+		"decision":           evaluation.decision,
+		"allowed":            evaluation.decision == decisionAllow,
+		"matching_rule":      evaluation.matchingRule,
+		"reason":             evaluation.reason,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return utils.CreateError("Unable to evaluate network access policy",
+				fmt.Errorf(utils.ErrorSettingFieldFmt, key, err).Error())
+		}
+	}
+	d.SetId(utils.MarshalComposedID([]string{repoID, sourceIP, dbAccount}, "/"))
+
+	tflog.Debug(ctx, "End dataSourceNetworkAccessEvaluationRead")
+	return nil
+}
+
+// getNetworkAccessPolicy retrieves the network access policy of the
+// repository. Repositories without a network access policy are returned as
+// a disabled policy without rules.
+func getNetworkAccessPolicy(ctx context.Context, c *client.Client, repoID string) (*NetworkAccessPolicy, error) {
+	url := fmt.Sprintf("https://%s/v1/repos/%s/networkAccessPolicy", c.ControlPlane, repoID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return &NetworkAccessPolicy{}, nil
+		}
+		return nil, err
+	}
+	nap := &NetworkAccessPolicy{}
+	if err := json.Unmarshal(body, nap); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", nap))
+	return nap, nil
+}
+
+type networkAccessEvaluation struct {
+	decision     string
+	matchingRule string
+	reason       string
+}
+
+// evaluate decides whether a connection from the given address using the
+// given database account is allowed by the policy.
+func (nap *NetworkAccessPolicy) evaluate(addr netip.Addr, dbAccount string) networkAccessEvaluation {
+	if !nap.Enabled {
+		return networkAccessEvaluation{
+			decision: decisionAllow,
+			reason:   "The network access policy is disabled.",
+		}
+	}
+	if len(nap.NetworkAccessRules.Rules) == 0 {
+		return networkAccessEvaluation{
+			decision: decisionAllow,
+			reason:   "The network access policy has no rules.",
+		}
+	}
+	blockAccess := nap.NetworkAccessRules.RulesBlockAccess
+	for _, rule := range nap.NetworkAccessRules.Rules {
+		if !rule.matches(addr, dbAccount) {
+			continue
+		}
+		if blockAccess {
+			return networkAccessEvaluation{
+				decision:     decisionDeny,
+				matchingRule: rule.Name,
+				reason:       fmt.Sprintf("The connection matches rule %q, and rules block access.", rule.Name),
+			}
+		}
+		return networkAccessEvaluation{
+			decision:     decisionAllow,
+			matchingRule: rule.Name,
+			reason:       fmt.Sprintf("The connection matches rule %q, and rules allow access.", rule.Name),
+		}
+	}
+	if blockAccess {
+		return networkAccessEvaluation{
+			decision: decisionAllow,
+			reason:   "The connection does not match any rule, and rules block access.",
+		}
+	}
+	return networkAccessEvaluation{
+		decision: decisionDeny,
+		reason:   "The connection does not match any rule, and only connections that match some rule are allowed.",
+	}
+}
+
+// matches checks whether the rule applies to the given address and database
+// account. Rules without database accounts or source IPs apply to any
+// account or address, respectively. Invalid source IPs never match.
+func (rule *NetworkAccessRule) matches(addr netip.Addr, dbAccount string) bool {
+	if len(rule.DBAccounts) > 0 && !slices.Contains(rule.DBAccounts, dbAccount) {
+		return false
+	}
+	if len(rule.SourceIPs) == 0 {
+		return true
+	}
+	for _, sourceIP := range rule.SourceIPs {
+		if prefix, err := parseSourceIP(sourceIP); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
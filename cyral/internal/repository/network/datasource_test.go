@@ -0,0 +1,94 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	networkAccessEvaluationDataSourceName = "data-network-access-evaluation"
+)
+
+func TestAccNetworkAccessEvaluationDataSource(t *testing.T) {
+	config := utils.FormatBasicRepositoryIntoConfig(
+		utils.BasicRepositoryResName,
+		utils.AccTestName(networkAccessEvaluationDataSourceName, "repo"),
+		"sqlserver",
+		"my.host.com",
+		1433,
+	)
+	config += fmt.Sprintf(`
+	resource "cyral_repository_network_access_policy" "policy" {
+		repository_id = %[1]s
+		network_access_rule {
+			name        = "office"
+			db_accounts = ["reader"]
+			source_ips  = ["10.0.0.0/24", "2001:db8::/32"]
+		}
+	}
+
+	data "cyral_network_access_evaluation" "office_reader" {
+		repository_id = cyral_repository_network_access_policy.policy.repository_id
+		source_ip     = "10.0.0.42"
+		db_account    = "reader"
+	}
+
+	data "cyral_network_access_evaluation" "office_reader_ipv6" {
+		repository_id = cyral_repository_network_access_policy.policy.repository_id
+		source_ip     = "2001:db8:1::1"
+		db_account    = "reader"
+	}
+
+	data "cyral_network_access_evaluation" "office_admin" {
+		repository_id = cyral_repository_network_access_policy.policy.repository_id
+		source_ip     = "10.0.0.42"
+		db_account    = "admin"
+	}
+
+	data "cyral_network_access_evaluation" "remote_reader" {
+		repository_id = cyral_repository_network_access_policy.policy.repository_id
+		source_ip     = "192.168.0.1"
+		db_account    = "reader"
+	}
+	`, utils.BasicRepositoryID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader",
+						"enabled", "true"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader",
+						"rules_block_access", "false"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader",
+						"decision", "allow"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader",
+						"allowed", "true"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader",
+						"matching_rule", "office"),
+
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader_ipv6",
+						"decision", "allow"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_reader_ipv6",
+						"matching_rule", "office"),
+
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_admin",
+						"decision", "deny"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.office_admin",
+						"matching_rule", ""),
+
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.remote_reader",
+						"decision", "deny"),
+					resource.TestCheckResourceAttr("data.cyral_network_access_evaluation.remote_reader",
+						"allowed", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkAccessPolicyEvaluate(t *testing.T) {
+	rules := []NetworkAccessRule{
+		{Name: "admins-office", DBAccounts: []string{"admin"}, SourceIPs: []string{"10.0.0.0/24"}},
+		{Name: "office", SourceIPs: []string{"10.0.0.0/16", "2001:db8::/32"}},
+		{Name: "readers-anywhere", DBAccounts: []string{"reader"}},
+		{Name: "invalid", SourceIPs: []string{"not-an-ip"}},
+	}
+	policy := func(enabled, blockAccess bool, rules []NetworkAccessRule) *NetworkAccessPolicy {
+		return &NetworkAccessPolicy{
+			Enabled: enabled,
+			NetworkAccessRules: NetworkAccessRules{
+				RulesBlockAccess: blockAccess,
+				Rules:            rules,
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc                 string
+		policy               *NetworkAccessPolicy
+		addr                 string
+		dbAccount            string
+		expectedDecision     string
+		expectedMatchingRule string
+	}{
+		{
+			desc:             "disabled policy allows everything",
+			policy:           policy(false, false, rules),
+			addr:             "192.168.0.1",
+			dbAccount:        "admin",
+			expectedDecision: decisionAllow,
+		},
+		{
+			desc:             "policy without rules allows everything",
+			policy:           policy(true, false, nil),
+			addr:             "192.168.0.1",
+			dbAccount:        "admin",
+			expectedDecision: decisionAllow,
+		},
+		{
+			desc:                 "first matching rule takes precedence",
+			policy:               policy(true, false, rules),
+			addr:                 "10.0.0.5",
+			dbAccount:            "admin",
+			expectedDecision:     decisionAllow,
+			expectedMatchingRule: "admins-office",
+		},
+		{
+			desc:                 "rule with other database accounts is skipped",
+			policy:               policy(true, false, rules),
+			addr:                 "10.0.0.5",
+			dbAccount:            "writer",
+			expectedDecision:     decisionAllow,
+			expectedMatchingRule: "office",
+		},
+		{
+			desc:                 "IPv6 address within CIDR",
+			policy:               policy(true, false, rules),
+			addr:                 "2001:db8::42",
+			dbAccount:            "writer",
+			expectedDecision:     decisionAllow,
+			expectedMatchingRule: "office",
+		},
+		{
+			desc:                 "rule without source IPs matches any address",
+			policy:               policy(true, false, rules),
+			addr:                 "192.168.0.1",
+			dbAccount:            "reader",
+			expectedDecision:     decisionAllow,
+			expectedMatchingRule: "readers-anywhere",
+		},
+		{
+			desc:             "no matching rule denies when rules allow access",
+			policy:           policy(true, false, rules),
+			addr:             "192.168.0.1",
+			dbAccount:        "writer",
+			expectedDecision: decisionDeny,
+		},
+		{
+			desc:                 "matching rule denies when rules block access",
+			policy:               policy(true, true, rules),
+			addr:                 "10.0.1.1",
+			dbAccount:            "writer",
+			expectedDecision:     decisionDeny,
+			expectedMatchingRule: "office",
+		},
+		{
+			desc:             "no matching rule allows when rules block access",
+			policy:           policy(true, true, rules),
+			addr:             "192.168.0.1",
+			dbAccount:        "writer",
+			expectedDecision: decisionAllow,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			evaluation := testCase.policy.evaluate(netip.MustParseAddr(testCase.addr), testCase.dbAccount)
+			assert.Equal(t, testCase.expectedDecision, evaluation.decision)
+			assert.Equal(t, testCase.expectedMatchingRule, evaluation.matchingRule)
+			assert.NotEmpty(t, evaluation.reason)
+		})
+	}
+}
@@ -52,7 +52,7 @@ func (nap *NetworkAccessPolicy) ReadFromSchema(d *schema.ResourceData) error {
 				Name:        networkAccessRuleMap["name"].(string),
 				Description: networkAccessRuleMap["description"].(string),
 				DBAccounts:  utils.GetStrList(networkAccessRuleMap, "db_accounts"),
-				SourceIPs:   utils.GetStrList(networkAccessRuleMap, "source_ips"),
+				SourceIPs:   normalizeSourceIPs(utils.GetStrList(networkAccessRuleMap, "source_ips")),
 			})
 	}
 
@@ -41,6 +41,9 @@ func resourceSchema() *schema.Resource {
 		ReadContext:   resourceContextHandler.ReadContext(),
 		UpdateContext: resourceContextHandler.UpdateContext(),
 		DeleteContext: resourceContextHandler.DeleteContext(),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateOverlappingRules,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
@@ -69,39 +72,13 @@ func resourceSchema() *schema.Resource {
 			},
 
 			"network_access_rule": {
-				Description: "Network access policy that decides whether access should be granted based on a set of rules.",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "Name of the rule.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"description": {
-							Description: "Description of the network access policy.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"db_accounts": {
-							Description: "Specify which accounts this rule applies to. The account name must match an existing account in your database.",
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"source_ips": {
-							Description: "Specify IPs to restrict the range of allowed IP addresses for this rule.",
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-					},
-				},
+				Description: "Network access policy that decides whether access should be granted based on a set of rules." +
+					" A warning is reported when rules that apply to the same database accounts have overlapping" +
+					" source IPs. Rules without `db_accounts` apply to all accounts.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      hashNetworkAccessRule,
+				Elem:     networkAccessRuleResource(),
 			},
 
 			"id": {
@@ -122,3 +99,41 @@ func resourceSchema() *schema.Resource {
 		},
 	}
 }
+
+func networkAccessRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the rule.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Description of the network access policy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"db_accounts": {
+				Description: "Specify which accounts this rule applies to. The account name must match an existing account in your database.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"source_ips": {
+				Description: "Specify IPs to restrict the range of allowed IP addresses for this rule. Each entry must be " +
+					"an IPv4 or IPv6 address, such as `10.0.0.1` or `2001:db8::1`, or CIDR, such as `10.0.0.0/24` or " +
+					"`2001:db8::/32`. Entries are normalized, so equivalent representations of the same address or " +
+					"CIDR, such as `10.0.0.1/32` and `10.0.0.1`, do not cause differences in the plan.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     validateSourceIP,
+					DiffSuppressFunc: suppressEquivalentSourceIPs,
+				},
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package network_test
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -90,6 +91,52 @@ func TestAccRepositoryNetworkAccessPolicyResource(t *testing.T) {
 
 	importResourceName := "cyral_repository_network_access_policy.full_rule"
 
+	// In these tests, equivalent representations of the same source IPs
+	// should not cause differences in the plan.
+	equivalentSourceIPs := &network.NetworkAccessPolicy{
+		NetworkAccessRules: network.NetworkAccessRules{
+			Rules: []network.NetworkAccessRule{
+				{
+					Name:      "name-5",
+					SourceIPs: []string{"10.0.0.1/24", "2001:DB8:0::1/128", "::ffff:192.168.0.1"},
+				},
+			},
+		},
+	}
+	normalizedSourceIPs := &network.NetworkAccessPolicy{
+		NetworkAccessRules: network.NetworkAccessRules{
+			Rules: []network.NetworkAccessRule{
+				{
+					Name:      "name-5",
+					SourceIPs: []string{"10.0.0.0/24", "2001:db8::1", "192.168.0.1"},
+				},
+			},
+		},
+	}
+	invalidSourceIPs := &network.NetworkAccessPolicy{
+		NetworkAccessRules: network.NetworkAccessRules{
+			Rules: []network.NetworkAccessRule{
+				{
+					Name:      "name-6",
+					SourceIPs: []string{"10.0.0.256/24"},
+				},
+			},
+		},
+	}
+	equivalentSourceIPsTest := resource.TestStep{
+		Config: setupRepositoryNetworkAccessPolicyConfig("source_ips", equivalentSourceIPs, nil),
+		Check:  setupRepositoryNetworkAccessPolicyCheck("source_ips", normalizedSourceIPs),
+	}
+	normalizedSourceIPsTest := resource.TestStep{
+		Config:   setupRepositoryNetworkAccessPolicyConfig("source_ips", normalizedSourceIPs, nil),
+		PlanOnly: true,
+	}
+	invalidSourceIPsTest := resource.TestStep{
+		Config:      setupRepositoryNetworkAccessPolicyConfig("source_ips", invalidSourceIPs, nil),
+		ExpectError: regexp.MustCompile("must be an IPv4 or IPv6 address or CIDR"),
+		PlanOnly:    true,
+	}
+
 	resource.ParallelTest(t, resource.TestCase{
 		ProviderFactories: provider.ProviderFactories,
 		Steps: []resource.TestStep{
@@ -108,6 +155,11 @@ func TestAccRepositoryNetworkAccessPolicyResource(t *testing.T) {
 				ImportStateVerify: true,
 				ResourceName:      importResourceName,
 			},
+
+			// Source IPs tests
+			equivalentSourceIPsTest,
+			normalizedSourceIPsTest,
+			invalidSourceIPsTest,
 		},
 	})
 }
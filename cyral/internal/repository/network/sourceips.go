@@ -0,0 +1,236 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// parseSourceIP parses an IPv4 or IPv6 address or CIDR. Addresses are
+// parsed as single-address prefixes, and the host bits of CIDRs are
+// cleared, so that equivalent entries result in the same prefix.
+func parseSourceIP(sourceIP string) (netip.Prefix, error) {
+	sourceIP = strings.TrimSpace(sourceIP)
+	if strings.Contains(sourceIP, "/") {
+		prefix, err := netip.ParsePrefix(sourceIP)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: %w", sourceIP, err)
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(sourceIP)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q: %w", sourceIP, err)
+	}
+	if addr.Zone() != "" {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q: zones are not supported", sourceIP)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// normalizeSourceIP returns the canonical representation of an IPv4 or IPv6
+// address or CIDR. Single addresses are represented without a prefix length,
+// such as `10.0.0.1` for `10.0.0.1/32`, and IPv6 addresses are represented
+// in their compressed, lower case form.
+func normalizeSourceIP(sourceIP string) (string, error) {
+	prefix, err := parseSourceIP(sourceIP)
+	if err != nil {
+		return "", err
+	}
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String(), nil
+	}
+	return prefix.String(), nil
+}
+
+func normalizeSourceIPs(sourceIPs []string) []string {
+	normalized := make([]string, 0, len(sourceIPs))
+	for _, sourceIP := range sourceIPs {
+		if n, err := normalizeSourceIP(sourceIP); err == nil {
+			sourceIP = n
+		}
+		normalized = append(normalized, sourceIP)
+	}
+	return normalized
+}
+
+func validateSourceIP(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if _, err := parseSourceIP(v); err != nil {
+		return nil, []error{fmt.Errorf("%q must be an IPv4 or IPv6 address or CIDR: %w", k, err)}
+	}
+	return nil, nil
+}
+
+func validateSourceAddress(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if _, err := netip.ParseAddr(v); err != nil {
+		return nil, []error{fmt.Errorf("%q must be an IPv4 or IPv6 address: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// suppressEquivalentSourceIPs ignores differences between equivalent
+// representations of the same address or CIDR.
+func suppressEquivalentSourceIPs(_, old, new string, _ *schema.ResourceData) bool {
+	normalizedOld, err := normalizeSourceIP(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := normalizeSourceIP(new)
+	if err != nil {
+		return false
+	}
+	return normalizedOld == normalizedNew
+}
+
+// hashNetworkAccessRule hashes the rule with normalized source IPs, so that
+// equivalent rules are identified as the same element of the set.
+func hashNetworkAccessRule(v interface{}) int {
+	rule := v.(map[string]interface{})
+	normalized := make(map[string]interface{}, len(rule))
+	for key, value := range rule {
+		normalized[key] = value
+	}
+	if sourceIPs, ok := rule["source_ips"].([]interface{}); ok {
+		normalizedSourceIPs := make([]interface{}, 0, len(sourceIPs))
+		for _, sourceIP := range sourceIPs {
+			if s, ok := sourceIP.(string); ok {
+				if n, err := normalizeSourceIP(s); err == nil {
+					sourceIP = n
+				}
+			}
+			normalizedSourceIPs = append(normalizedSourceIPs, sourceIP)
+		}
+		normalized["source_ips"] = normalizedSourceIPs
+	}
+	return schema.HashResource(networkAccessRuleResource())(normalized)
+}
+
+// overlappingRulesWarnings returns one warning for each pair of rules that
+// apply to a common database account and whose source IPs overlap. Rules
+// without database accounts apply to all accounts.
+func overlappingRulesWarnings(rules []NetworkAccessRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i := 0; i < len(rules); i++ {
+		for j := i + 1; j < len(rules); j++ {
+			accounts, ok := commonDBAccounts(rules[i].DBAccounts, rules[j].DBAccounts)
+			if !ok {
+				continue
+			}
+			overlaps := overlappingSourceIPs(rules[i].SourceIPs, rules[j].SourceIPs)
+			if len(overlaps) == 0 {
+				continue
+			}
+			accountsDetail := "all database accounts"
+			if len(accounts) > 0 {
+				accountsDetail = "database accounts " + strings.Join(accounts, ", ")
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Overlapping network access rules %q and %q", rules[i].Name, rules[j].Name),
+				Detail: fmt.Sprintf("Both rules apply to %s and have overlapping source IPs: %s.",
+					accountsDetail, strings.Join(overlaps, ", ")),
+			})
+		}
+	}
+	return diags
+}
+
+// commonDBAccounts returns the database accounts targeted by both rules, and
+// whether the rules target any account in common. An empty result with ok
+// set means that both rules apply to all accounts.
+func commonDBAccounts(a, b []string) ([]string, bool) {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return nil, true
+	case len(a) == 0:
+		return b, true
+	case len(b) == 0:
+		return a, true
+	}
+	inA := make(map[string]bool, len(a))
+	for _, account := range a {
+		inA[account] = true
+	}
+	var common []string
+	for _, account := range b {
+		if inA[account] {
+			common = append(common, account)
+			delete(inA, account)
+		}
+	}
+	sort.Strings(common)
+	return common, len(common) > 0
+}
+
+// overlappingSourceIPs returns the pairs of overlapping entries of the given
+// lists, formatted as `a and b`. Rules without source IPs apply to all
+// addresses and overlap with any other list.
+func overlappingSourceIPs(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		return []string{"any address"}
+	}
+	var overlaps []string
+	for _, ipA := range a {
+		prefixA, err := parseSourceIP(ipA)
+		if err != nil {
+			continue
+		}
+		for _, ipB := range b {
+			prefixB, err := parseSourceIP(ipB)
+			if err != nil {
+				continue
+			}
+			if prefixA.Overlaps(prefixB) {
+				overlaps = append(overlaps, fmt.Sprintf("%s and %s", ipA, ipB))
+			}
+		}
+	}
+	return overlaps
+}
+
+// validateOverlappingRules reports overlapping rules at plan time. Rules
+// whose name, database accounts or source IPs are not known yet are ignored.
+func validateOverlappingRules(
+	_ context.Context,
+	req schema.ValidateResourceConfigFuncRequest,
+	resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	blocks, ok := utils.RawConfigBlocks(req.RawConfig, "network_access_rule")
+	if !ok {
+		return
+	}
+	var rules []NetworkAccessRule
+	for _, block := range blocks {
+		name, nameKnown := utils.RawConfigString(block, "name")
+		dbAccounts, dbAccountsKnown := utils.RawConfigStrings(block, "db_accounts")
+		sourceIPs, sourceIPsKnown := utils.RawConfigStrings(block, "source_ips")
+		if !nameKnown || !dbAccountsKnown || !sourceIPsKnown {
+			continue
+		}
+		rules = append(rules, NetworkAccessRule{
+			Name:       name,
+			DBAccounts: dbAccounts,
+			SourceIPs:  sourceIPs,
+		})
+	}
+	resp.Diagnostics = append(resp.Diagnostics, overlappingRulesWarnings(rules)...)
+}
@@ -0,0 +1,192 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSourceIP(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sourceIP    string
+		expected    string
+		expectError bool
+	}{
+		{desc: "IPv4 address", sourceIP: "10.0.0.1", expected: "10.0.0.1"},
+		{desc: "IPv4 single address CIDR", sourceIP: "10.0.0.1/32", expected: "10.0.0.1"},
+		{desc: "IPv4 CIDR with host bits", sourceIP: "10.0.0.7/24", expected: "10.0.0.0/24"},
+		{desc: "surrounding spaces", sourceIP: " 10.0.0.0/8 ", expected: "10.0.0.0/8"},
+		{desc: "IPv6 address", sourceIP: "2001:DB8:0:0::1", expected: "2001:db8::1"},
+		{desc: "IPv6 single address CIDR", sourceIP: "2001:db8::1/128", expected: "2001:db8::1"},
+		{desc: "IPv6 CIDR with host bits", sourceIP: "2001:db8::1/32", expected: "2001:db8::/32"},
+		{desc: "IPv4-mapped IPv6 address", sourceIP: "::ffff:10.0.0.1", expected: "10.0.0.1"},
+		{desc: "IPv4-mapped IPv6 CIDR", sourceIP: "::ffff:10.0.0.0/120", expected: "10.0.0.0/24"},
+		{desc: "invalid address", sourceIP: "10.0.0.256", expectError: true},
+		{desc: "invalid prefix length", sourceIP: "10.0.0.0/33", expectError: true},
+		{desc: "zone", sourceIP: "fe80::1%eth0", expectError: true},
+		{desc: "hostname", sourceIP: "db.example.com", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			normalized, err := normalizeSourceIP(testCase.sourceIP)
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, normalized)
+		})
+	}
+}
+
+func TestOverlappingRulesWarnings(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		rules             []NetworkAccessRule
+		expectedSummaries []string
+	}{
+		{
+			desc: "disjoint source IPs",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"10.0.0.0/24"}},
+				{Name: "b", SourceIPs: []string{"10.0.1.0/24"}},
+			},
+		},
+		{
+			desc: "address within CIDR",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"10.0.0.0/16"}},
+				{Name: "b", SourceIPs: []string{"10.0.3.4"}},
+			},
+			expectedSummaries: []string{`Overlapping network access rules "a" and "b"`},
+		},
+		{
+			desc: "equivalent representations",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"2001:db8::1"}},
+				{Name: "b", SourceIPs: []string{"2001:DB8::1/128"}},
+			},
+			expectedSummaries: []string{`Overlapping network access rules "a" and "b"`},
+		},
+		{
+			desc: "different database accounts",
+			rules: []NetworkAccessRule{
+				{Name: "a", DBAccounts: []string{"admin"}, SourceIPs: []string{"10.0.0.0/8"}},
+				{Name: "b", DBAccounts: []string{"reader"}, SourceIPs: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			desc: "rule without database accounts applies to all accounts",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"10.0.0.0/8"}},
+				{Name: "b", DBAccounts: []string{"reader"}, SourceIPs: []string{"10.0.0.1"}},
+			},
+			expectedSummaries: []string{`Overlapping network access rules "a" and "b"`},
+		},
+		{
+			desc: "rule without source IPs applies to all addresses",
+			rules: []NetworkAccessRule{
+				{Name: "a", DBAccounts: []string{"admin"}},
+				{Name: "b", DBAccounts: []string{"admin", "reader"}, SourceIPs: []string{"10.0.0.1"}},
+			},
+			expectedSummaries: []string{`Overlapping network access rules "a" and "b"`},
+		},
+		{
+			desc: "IPv4 and IPv6 do not overlap",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"0.0.0.0/0"}},
+				{Name: "b", SourceIPs: []string{"::/0"}},
+			},
+		},
+		{
+			desc: "one warning per overlapping pair",
+			rules: []NetworkAccessRule{
+				{Name: "a", SourceIPs: []string{"10.0.0.0/8"}},
+				{Name: "b", SourceIPs: []string{"10.1.0.0/16"}},
+				{Name: "c", SourceIPs: []string{"10.1.2.3"}},
+			},
+			expectedSummaries: []string{
+				`Overlapping network access rules "a" and "b"`,
+				`Overlapping network access rules "a" and "c"`,
+				`Overlapping network access rules "b" and "c"`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			diags := overlappingRulesWarnings(testCase.rules)
+			var summaries []string
+			for _, d := range diags {
+				assert.Equal(t, diag.Warning, d.Severity)
+				summaries = append(summaries, d.Summary)
+			}
+			assert.Equal(t, testCase.expectedSummaries, summaries)
+		})
+	}
+}
+
+func TestValidateOverlappingRules(t *testing.T) {
+	rule := func(name cty.Value, sourceIPs cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name":        name,
+			"description": cty.NullVal(cty.String),
+			"db_accounts": cty.NullVal(cty.List(cty.String)),
+			"source_ips":  sourceIPs,
+		})
+	}
+	config := func(rules ...cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"repository_id":       cty.StringVal("repo-id"),
+			"network_access_rule": cty.SetVal(rules),
+		})
+	}
+
+	testCases := []struct {
+		desc          string
+		rawConfig     cty.Value
+		expectedDiags int
+	}{
+		{
+			desc: "overlapping rules",
+			rawConfig: config(
+				rule(cty.StringVal("a"), cty.ListVal([]cty.Value{cty.StringVal("10.0.0.0/8")})),
+				rule(cty.StringVal("b"), cty.ListVal([]cty.Value{cty.StringVal("10.0.0.1")})),
+			),
+			expectedDiags: 1,
+		},
+		{
+			desc: "rules with unknown source IPs are ignored",
+			rawConfig: config(
+				rule(cty.StringVal("a"), cty.ListVal([]cty.Value{cty.StringVal("10.0.0.0/8")})),
+				rule(cty.StringVal("b"), cty.ListVal([]cty.Value{cty.UnknownVal(cty.String)})),
+			),
+		},
+		{
+			desc: "unknown rules are ignored",
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"repository_id":       cty.StringVal("repo-id"),
+				"network_access_rule": cty.UnknownVal(cty.Set(cty.EmptyObject)),
+			}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateOverlappingRules(
+				context.Background(),
+				schema.ValidateResourceConfigFuncRequest{RawConfig: testCase.rawConfig},
+				resp,
+			)
+			assert.Len(t, resp.Diagnostics, testCase.expectedDiags)
+		})
+	}
+}
@@ -25,178 +25,318 @@ var resourceContextHandler = core.HTTPContextHandler{
 }
 
 func resourceSchema() *schema.Resource {
+	repoSchema := RepoInfoSchema()
+	repoSchema[RepoIDKey] = &schema.Schema{
+		Description: "ID of this resource in Cyral environment.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	repoSchema[RepoTypeKey].ForceNew = true
+	repoSchema[RepoNameKey].ForceNew = true
+
 	return &schema.Resource{
 		Description:   "Manages [repositories](https://cyral.com/docs/how-to/track-repos/).",
 		CreateContext: resourceContextHandler.CreateContext(),
 		ReadContext:   resourceContextHandler.ReadContext(),
 		UpdateContext: resourceContextHandler.UpdateContext(),
 		DeleteContext: resourceContextHandler.DeleteContext(),
-		Schema: map[string]*schema.Schema{
-			RepoIDKey: {
-				Description: "ID of this resource in Cyral environment.",
-				Type:        schema.TypeString,
-				Computed:    true,
+		Schema:        repoSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// RepoInfoSchema returns the schema of the repository attributes, without
+// the repository ID. It is shared by all the resources that manage
+// repositories.
+func RepoInfoSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		RepoTypeKey: {
+			Description:  "Repository type. List of supported types:" + utils.SupportedValuesAsMarkdown(RepositoryTypes()),
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(RepositoryTypes(), false),
+		},
+		RepoNameKey: {
+			Description: "Repository name that will be used internally in the control plane (ex: `your_repo_name`).",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		RepoLabelsKey: {
+			Description: "Labels enable you to categorize your repository.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		RepoConnDrainingKey: {
+			Description: "Parameters related to connection draining.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoConnDrainingAutoKey: {
+						Description: "Whether connections should be drained automatically after a listener dies.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+					},
+					RepoConnDrainingWaitTimeKey: {
+						Description: "Seconds to wait to let connections drain before starting to kill all the connections, " +
+							"if auto is set to true.",
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
 			},
-			RepoTypeKey: {
-				Description:  "Repository type. List of supported types:" + utils.SupportedValuesAsMarkdown(RepositoryTypes()),
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(RepositoryTypes(), false),
+		},
+		RepoNodesKey: {
+			Description: "List of nodes for this repository.",
+			Type:        schema.TypeList,
+			Required:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoNameKey: {
+						Description: "Name of the repo node.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoHostKey: {
+						Description: "Repo node host (ex: `somerepo.cyral.com`). Can be empty if node is dynamic.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoPortKey: {
+						Description: "Repository access port (ex: `3306`). Can be empty if node is dynamic.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+					},
+					RepoNodeDynamicKey: {
+						Description: "*Only supported for MongoDB in cluster configurations.*\n" +
+							"Indicates if the node is dynamically discovered, meaning that the sidecar " +
+							"will query the cluster to get the topology information and discover the " +
+							"addresses of the dynamic nodes. If set to `true`, `host` and `port` must " +
+							"be empty. A node with value of this field as false considered `static`.\n" +
+							"The following conditions apply: \n" +
+							"  - The total number of declared `" + RepoNodesKey + "` blocks must match " +
+							"the actual number of nodes in the cluster.\n" +
+							"  - If there are static nodes in the configuration, they must be declared " +
+							"before all dynamic nodes.\n" +
+							"  - See the MongoDB-specific configuration in the [" + RepoMongoDBSettingsKey +
+							"](#nested-schema-for-" + RepoMongoDBSettingsKey + ").",
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
 			},
-			RepoNameKey: {
-				Description: "Repository name that will be used internally in the control plane (ex: `your_repo_name`).",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+		},
+		RepoMongoDBSettingsKey: {
+			Description: "Parameters related to MongoDB repositories.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoMongoDBReplicaSetNameKey: {
+						Description: "Name of the replica set, if applicable.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoMongoDBServerTypeKey: {
+						Description: "Type of the MongoDB server. Allowed values: " + utils.SupportedValuesAsMarkdown(mongoServerTypes()) +
+							"\n\n  The following conditions apply:\n" +
+							"  - If `" + Sharded + "` and `" + RepoMongoDBSRVRecordName + "` *not* provided, then all `" +
+							RepoNodesKey + "` blocks must be static (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
+							"  - If `" + Sharded + "` and `" + RepoMongoDBSRVRecordName + "` provided, then all `" +
+							RepoNodesKey + "` blocks must be dynamic (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
+							"  - If `" + Standalone + "`, then only one `" + RepoNodesKey +
+							"` block can be declared and it must be static (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")). The `" +
+							RepoMongoDBSRVRecordName + "` is not supported in this configuration.\n" +
+							"  - If `" + ReplicaSet + "` and `" + RepoMongoDBSRVRecordName + "` *not* provided, then `" +
+							RepoNodesKey + "` blocks may mix dynamic and static nodes (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
+							"  - If `" + ReplicaSet + "` and `" + RepoMongoDBSRVRecordName + "` provided, then `" +
+							RepoNodesKey + "` blocks must be dynamic (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(mongoServerTypes(), false),
+					},
+					RepoMongoDBSRVRecordName: {
+						Description: "Name of a DNS SRV record which contains cluster topology details. " +
+							"If specified, then all `" + RepoNodesKey + "` blocks must be declared dynamic " +
+							"(see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")). " +
+							"Only supported for `" + RepoMongoDBServerTypeKey + "=\"" + Sharded + "\"` or `" +
+							RepoMongoDBServerTypeKey + "=\"" + ReplicaSet + "\".",
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					RepoMongoDBFlavorKey: {
+						Description: "The flavor of the MongoDB deployment. Allowed values: " + utils.SupportedValuesAsMarkdown(mongoFlavors()) +
+							"\n\n  The following conditions apply:\n" +
+							"  - The `" + MongoDBFlavorDocumentDB + "` flavor cannot be combined with the MongoDB Server type `" + Sharded + "`.\n",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(mongoFlavors(), false),
+					},
+				},
 			},
-			RepoLabelsKey: {
-				Description: "Labels enable you to categorize your repository.",
-				Type:        schema.TypeList,
-				Optional:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		RepoRedshiftSettingsKey: {
+			Description: "Parameters related to Redshift repositories.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoRedshiftClusterIdentifier: {
+						Description: "Name of the provisioned cluster.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoRedshiftWorkgroupName: {
+						Description: "Workgroup name for serverless cluster.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoRedshiftAWSRegion: {
+						Description: "Code of the AWS region where the Redshift instance is deployed.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
 				},
 			},
-			RepoConnDrainingKey: {
-				Description: "Parameters related to connection draining.",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						RepoConnDrainingAutoKey: {
-							Description: "Whether connections should be drained automatically after a listener dies.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-						},
-						RepoConnDrainingWaitTimeKey: {
-							Description: "Seconds to wait to let connections drain before starting to kill all the connections, " +
-								"if auto is set to true.",
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
+		},
+		RepoOracleSettingsKey: {
+			Description: "Parameters related to Oracle repositories. Only allowed when `" + RepoTypeKey + "=\"" + Oracle + "\"`.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoOracleServiceNameKey: {
+						Description: "Name of the Oracle service the sidecar connects to. Conflicts with `" +
+							RepoOracleSIDKey + "`, and one of them must be provided.",
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					RepoOracleSIDKey: {
+						Description: "System identifier (SID) of the Oracle database the sidecar connects to. " +
+							"Conflicts with `" + RepoOracleServiceNameKey + "`, and one of them must be provided.",
+						Type:     schema.TypeString,
+						Optional: true,
 					},
 				},
 			},
-			RepoNodesKey: {
-				Description: "List of nodes for this repository.",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						RepoNameKey: {
-							Description: "Name of the repo node.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						RepoHostKey: {
-							Description: "Repo node host (ex: `somerepo.cyral.com`). Can be empty if node is dynamic.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						RepoPortKey: {
-							Description: "Repository access port (ex: `3306`). Can be empty if node is dynamic.",
-							Type:        schema.TypeInt,
-							Optional:    true,
-						},
-						RepoNodeDynamicKey: {
-							Description: "*Only supported for MongoDB in cluster configurations.*\n" +
-								"Indicates if the node is dynamically discovered, meaning that the sidecar " +
-								"will query the cluster to get the topology information and discover the " +
-								"addresses of the dynamic nodes. If set to `true`, `host` and `port` must " +
-								"be empty. A node with value of this field as false considered `static`.\n" +
-								"The following conditions apply: \n" +
-								"  - The total number of declared `" + RepoNodesKey + "` blocks must match " +
-								"the actual number of nodes in the cluster.\n" +
-								"  - If there are static nodes in the configuration, they must be declared " +
-								"before all dynamic nodes.\n" +
-								"  - See the MongoDB-specific configuration in the [" + RepoMongoDBSettingsKey +
-								"](#nested-schema-for-" + RepoMongoDBSettingsKey + ").",
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
+		},
+		RepoSnowflakeSettingsKey: {
+			Description: "Parameters related to Snowflake repositories. Only allowed when `" + RepoTypeKey + "=\"" + Snowflake + "\"`.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoSnowflakeAccountKey: {
+						Description: "Snowflake account identifier (ex: `myorg-account123`).",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					RepoSnowflakeWarehouseKey: {
+						Description: "Default Snowflake warehouse used by the connections.",
+						Type:        schema.TypeString,
+						Optional:    true,
 					},
 				},
 			},
-			RepoMongoDBSettingsKey: {
-				Description: "Parameters related to MongoDB repositories.",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						RepoMongoDBReplicaSetNameKey: {
-							Description: "Name of the replica set, if applicable.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						RepoMongoDBServerTypeKey: {
-							Description: "Type of the MongoDB server. Allowed values: " + utils.SupportedValuesAsMarkdown(mongoServerTypes()) +
-								"\n\n  The following conditions apply:\n" +
-								"  - If `" + Sharded + "` and `" + RepoMongoDBSRVRecordName + "` *not* provided, then all `" +
-								RepoNodesKey + "` blocks must be static (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
-								"  - If `" + Sharded + "` and `" + RepoMongoDBSRVRecordName + "` provided, then all `" +
-								RepoNodesKey + "` blocks must be dynamic (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
-								"  - If `" + Standalone + "`, then only one `" + RepoNodesKey +
-								"` block can be declared and it must be static (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")). The `" +
-								RepoMongoDBSRVRecordName + "` is not supported in this configuration.\n" +
-								"  - If `" + ReplicaSet + "` and `" + RepoMongoDBSRVRecordName + "` *not* provided, then `" +
-								RepoNodesKey + "` blocks may mix dynamic and static nodes (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n" +
-								"  - If `" + ReplicaSet + "` and `" + RepoMongoDBSRVRecordName + "` provided, then `" +
-								RepoNodesKey + "` blocks must be dynamic (see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")).\n",
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringInSlice(mongoServerTypes(), false),
-						},
-						RepoMongoDBSRVRecordName: {
-							Description: "Name of a DNS SRV record which contains cluster topology details. " +
-								"If specified, then all `" + RepoNodesKey + "` blocks must be declared dynamic " +
-								"(see [`" + RepoNodeDynamicKey + "`](#" + RepoNodeDynamicKey + ")). " +
-								"Only supported for `" + RepoMongoDBServerTypeKey + "=\"" + Sharded + "\"` or `" +
-								RepoMongoDBServerTypeKey + "=\"" + ReplicaSet + "\".",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						RepoMongoDBFlavorKey: {
-							Description: "The flavor of the MongoDB deployment. Allowed values: " + utils.SupportedValuesAsMarkdown(mongoFlavors()) +
-								"\n\n  The following conditions apply:\n" +
-								"  - The `" + MongoDBFlavorDocumentDB + "` flavor cannot be combined with the MongoDB Server type `" + Sharded + "`.\n",
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringInSlice(mongoFlavors(), false),
-						},
+		},
+		RepoSQLServerSettingsKey: {
+			Description: "Parameters related to SQL Server repositories. Only allowed when `" + RepoTypeKey + "=\"" + SQLServer + "\"`.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoSQLServerInstanceNameKey: {
+						Description: "Name of the SQL Server named instance (ex: `SQLEXPRESS`).",
+						Type:        schema.TypeString,
+						Required:    true,
 					},
 				},
 			},
-			RepoRedshiftSettingsKey: {
-				Description: "Parameters related to Redshift repositories.",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						RepoRedshiftClusterIdentifier: {
-							Description: "Name of the provisioned cluster.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						RepoRedshiftWorkgroupName: {
-							Description: "Workgroup name for serverless cluster.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						RepoRedshiftAWSRegion: {
-							Description: "Code of the AWS region where the Redshift instance is deployed.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
+		},
+		RepoPostgreSQLSettingsKey: {
+			Description: "Parameters related to PostgreSQL repositories. Only allowed when `" + RepoTypeKey + "=\"" + PostgreSQL + "\"`.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RepoPostgreSQLSSLModeKey: {
+						Description: "SSL mode used by the sidecar to connect to the repository. List of supported values:" +
+							utils.SupportedValuesAsMarkdown(postgreSQLSSLModes()),
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(postgreSQLSSLModes(), false),
+					},
+					RepoPostgreSQLClusterIdentifierKey: {
+						Description: "Identifier of the RDS or Aurora cluster, if applicable.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					RepoPostgreSQLAWSRegionKey: {
+						Description:  "Code of the AWS region where the RDS or Aurora cluster is deployed (ex: `us-east-1`).",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateAWSRegion,
 					},
 				},
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		RepoS3SettingsKey: {
+			Description: "Parameters related to S3 repositories. Only allowed when `" + RepoTypeKey + "=\"" + S3 + "\"`.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: awsServiceSettingsSchema(),
+			},
+		},
+		RepoDynamoDBSettingsKey: {
+			Description: "Parameters related to DynamoDB repositories. Only allowed when `" + RepoTypeKey + "` is `" +
+				DynamoDB + "` or `" + DynamoDBStreams + "`.",
+			Type:     schema.TypeSet,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: awsServiceSettingsSchema(),
+			},
+		},
+	}
+}
+
+// validateAWSRegion adapts client.ValidateAWSRegion to be used as the
+// validation function of a schema attribute.
+func validateAWSRegion(v interface{}, k string) ([]string, []error) {
+	if err := client.ValidateAWSRegion(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+func awsServiceSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		RepoAWSRegionKey: {
+			Description:  "Code of the AWS region where the service is accessed (ex: `us-east-1`).",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateAWSRegion,
+		},
+		RepoAWSEndpointKey: {
+			Description: "Custom endpoint URL used to reach the service, such as a VPC or FIPS " +
+				"endpoint (ex: `https://bucket.vpce-1a2b3c4d-5e6f.s3.us-east-1.vpce.amazonaws.com`).",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 		},
 	}
 }
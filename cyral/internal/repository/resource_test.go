@@ -157,6 +157,53 @@ var (
 	}
 )
 
+var (
+	withOracleSettings = repository.RepoInfo{
+		Name: utils.AccTestName(utils.RepositoryResourceName, "repo-oracle"),
+		Type: repository.Oracle,
+		RepoNodes: repository.RepoNodes{
+			{
+				Host: "oracle.local",
+				Port: 1521,
+			},
+		},
+		OracleSettings: &repository.OracleSettings{
+			ServiceName: "ORCLPDB1",
+		},
+	}
+
+	withPostgreSQLSettings = repository.RepoInfo{
+		Name: utils.AccTestName(utils.RepositoryResourceName, "repo-pg"),
+		Type: repository.PostgreSQL,
+		RepoNodes: repository.RepoNodes{
+			{
+				Host: "pg.local",
+				Port: 5432,
+			},
+		},
+		PostgreSQLSettings: &repository.PostgreSQLSettings{
+			SSLMode:           repository.SSLModeVerifyFull,
+			ClusterIdentifier: "my-aurora-cluster",
+			AWSRegion:         "us-east-1",
+		},
+	}
+
+	withS3Settings = repository.RepoInfo{
+		Name: utils.AccTestName(utils.RepositoryResourceName, "repo-s3"),
+		Type: repository.S3,
+		RepoNodes: repository.RepoNodes{
+			{
+				Host: "s3.amazonaws.com",
+				Port: 443,
+			},
+		},
+		S3Settings: &repository.AWSServiceSettings{
+			AWSRegion: "us-west-2",
+			Endpoint:  "https://s3.us-west-2.amazonaws.com",
+		},
+	}
+)
+
 func TestAccRepositoryResource(t *testing.T) {
 	initial := setupRepositoryTest(
 		initialRepoConfig, "update_test")
@@ -170,6 +217,12 @@ func TestAccRepositoryResource(t *testing.T) {
 		allRepoNodesAreDynamic, "all_repo_nodes_are_dynamic")
 	redshift := setupRepositoryTest(
 		withRedshiftSettings, "with_redshift_settings")
+	oracle := setupRepositoryTest(
+		withOracleSettings, "with_oracle_settings")
+	postgresql := setupRepositoryTest(
+		withPostgreSQLSettings, "with_postgresql_settings")
+	s3 := setupRepositoryTest(
+		withS3Settings, "with_s3_settings")
 
 	multiNode := setupRepositoryTest(
 		mixedMultipleNodesConfig, "multi_node_test")
@@ -189,6 +242,9 @@ func TestAccRepositoryResource(t *testing.T) {
 			connDrainingEmpty,
 			connDraining,
 			redshift,
+			oracle,
+			postgresql,
+			s3,
 			allDynamic,
 			multiNode,
 			importTest,
@@ -291,6 +347,49 @@ func repoCheckFuctions(repo repository.RepoInfo, resName string) resource.TestCh
 		}...)
 	}
 
+	if repo.OracleSettings != nil {
+		checkFuncs = append(checkFuncs, []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(resourceFullName,
+				"oracle_settings.0.service_name",
+				repo.OracleSettings.ServiceName,
+			),
+			resource.TestCheckResourceAttr(resourceFullName,
+				"oracle_settings.0.sid",
+				repo.OracleSettings.SID,
+			),
+		}...)
+	}
+
+	if repo.PostgreSQLSettings != nil {
+		checkFuncs = append(checkFuncs, []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(resourceFullName,
+				"postgresql_settings.0.ssl_mode",
+				repo.PostgreSQLSettings.SSLMode,
+			),
+			resource.TestCheckResourceAttr(resourceFullName,
+				"postgresql_settings.0.cluster_identifier",
+				repo.PostgreSQLSettings.ClusterIdentifier,
+			),
+			resource.TestCheckResourceAttr(resourceFullName,
+				"postgresql_settings.0.aws_region",
+				repo.PostgreSQLSettings.AWSRegion,
+			),
+		}...)
+	}
+
+	if repo.S3Settings != nil {
+		checkFuncs = append(checkFuncs, []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(resourceFullName,
+				"s3_settings.0.aws_region",
+				repo.S3Settings.AWSRegion,
+			),
+			resource.TestCheckResourceAttr(resourceFullName,
+				"s3_settings.0.endpoint",
+				repo.S3Settings.Endpoint,
+			),
+		}...)
+	}
+
 	return resource.ComposeTestCheckFunc(checkFuncs...)
 }
 
@@ -371,6 +470,41 @@ func repoAsConfig(repo repository.RepoInfo, resName string) string {
 		)
 	}
 
+	if repo.OracleSettings != nil {
+		config += fmt.Sprintf(`
+			oracle_settings {
+				service_name = %s
+				sid = %s
+			}`,
+			nullableString(repo.OracleSettings.ServiceName),
+			nullableString(repo.OracleSettings.SID),
+		)
+	}
+
+	if repo.PostgreSQLSettings != nil {
+		config += fmt.Sprintf(`
+			postgresql_settings {
+				ssl_mode = %s
+				cluster_identifier = %s
+				aws_region = %s
+			}`,
+			nullableString(repo.PostgreSQLSettings.SSLMode),
+			nullableString(repo.PostgreSQLSettings.ClusterIdentifier),
+			nullableString(repo.PostgreSQLSettings.AWSRegion),
+		)
+	}
+
+	if repo.S3Settings != nil {
+		config += fmt.Sprintf(`
+			s3_settings {
+				aws_region = %s
+				endpoint = %s
+			}`,
+			nullableString(repo.S3Settings.AWSRegion),
+			nullableString(repo.S3Settings.Endpoint),
+		)
+	}
+
 	for _, node := range repo.RepoNodes {
 		name, host := "null", "null"
 		if node.Name != "" {
@@ -393,3 +527,10 @@ func repoAsConfig(repo repository.RepoInfo, resName string) string {
 
 	return config
 }
+
+func nullableString(s string) string {
+	if s == "" {
+		return "null"
+	}
+	return fmt.Sprintf(`"%s"`, s)
+}
@@ -0,0 +1,32 @@
+package stack
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confanalysis"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// confAnalysisSchema returns the settings of the conf_analysis block, along
+// with the configuration effectively applied by the sidecar.
+func confAnalysisSchema() map[string]*schema.Schema {
+	s := confanalysis.ConfigSchema(ConfAnalysisKey + ".0.")
+	s[confanalysis.EffectiveConfigKey] = confanalysis.EffectiveConfigSchema()
+	return s
+}
+
+// validateConfAnalysisContradictions reports the contradictory settings of
+// the conf_analysis block at plan time, like the
+// cyral_repository_conf_analysis resource.
+func validateConfAnalysisContradictions(
+	_ context.Context,
+	req schema.ValidateResourceConfigFuncRequest,
+	resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	confAnalysisBlocks, _ := utils.RawConfigBlocks(req.RawConfig, ConfAnalysisKey)
+	for _, confAnalysis := range confAnalysisBlocks {
+		resp.Diagnostics = append(resp.Diagnostics, confanalysis.ContradictionWarnings(confAnalysis)...)
+	}
+}
@@ -0,0 +1,71 @@
+package stack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confanalysis"
+)
+
+func TestValidateConfAnalysisContradictions(t *testing.T) {
+	config := func(blockOnViolation cty.Value, logGroups cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"sidecar_id": cty.StringVal("sidecar"),
+			ConfAnalysisKey: cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					confanalysis.AlertOnViolationKey:   cty.False,
+					confanalysis.BlockOnViolationKey:   blockOnViolation,
+					confanalysis.EnableDataMaskingKey:  cty.NullVal(cty.Bool),
+					confanalysis.MaskAllOccurrencesKey: cty.NullVal(cty.Bool),
+					confanalysis.LogGroupsKey:          logGroups,
+				}),
+			}),
+		})
+	}
+
+	testCases := []struct {
+		desc          string
+		rawConfig     cty.Value
+		expectedDiags int
+	}{
+		{
+			desc:      "no contradictions",
+			rawConfig: config(cty.False, cty.NullVal(cty.Set(cty.String))),
+		},
+		{
+			desc: "contradictions are reported",
+			rawConfig: config(cty.True,
+				cty.SetVal([]cty.Value{cty.StringVal("everything"), cty.StringVal("dql")})),
+			expectedDiags: 2,
+		},
+		{
+			desc: "no conf_analysis block",
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"sidecar_id":    cty.StringVal("sidecar"),
+				ConfAnalysisKey: cty.NullVal(cty.List(cty.EmptyObject)),
+			}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateConfAnalysisContradictions(
+				context.Background(),
+				schema.ValidateResourceConfigFuncRequest{RawConfig: testCase.rawConfig},
+				resp,
+			)
+			assert.Len(t, resp.Diagnostics, testCase.expectedDiags)
+		})
+	}
+}
+
+func TestConfAnalysisSchemaRequiresDataMasking(t *testing.T) {
+	maskAllOccurrences := confAnalysisSchema()[confanalysis.MaskAllOccurrencesKey]
+	assert.Equal(t, []string{ConfAnalysisKey + ".0." + confanalysis.EnableDataMaskingKey},
+		maskAllOccurrences.RequiredWith)
+}
@@ -0,0 +1,14 @@
+package stack
+
+const (
+	resourceName = "cyral_repository_stack"
+)
+
+const (
+	// Schema keys.
+	RepositoryKey     = "repository"
+	ListenerKey       = "listener"
+	BindingEnabledKey = "binding_enabled"
+	ConfAuthKey       = "conf_auth"
+	ConfAnalysisKey   = "conf_analysis"
+)
@@ -0,0 +1,236 @@
+package stack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/accessgateway"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/binding"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confanalysis"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confauth"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/listener"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// Stack holds the configuration of all the objects managed by a
+// cyral_repository_stack resource. ConfAuth and ConfAnalysis are nil when
+// the corresponding blocks are not set, in which case the defaults created
+// along with the repository are kept.
+type Stack struct {
+	SidecarID      string
+	Repository     *repository.RepoInfo
+	Listener       *listener.SidecarListener
+	BindingEnabled bool
+	ConfAuth       *confauth.RepositoryConfAuthData
+	ConfAnalysis   *confanalysis.UserConfig
+}
+
+func (s *Stack) ReadFromSchema(d *schema.ResourceData) error {
+	s.SidecarID = d.Get(utils.SidecarIDKey).(string)
+
+	repoList := d.Get(RepositoryKey).([]interface{})
+	if len(repoList) == 0 || repoList[0] == nil {
+		return fmt.Errorf("'%s' block is required", RepositoryKey)
+	}
+	s.Repository = &repository.RepoInfo{}
+	if err := s.Repository.ReadFromMap(repoList[0].(map[string]interface{})); err != nil {
+		return err
+	}
+
+	s.Listener = &listener.SidecarListener{
+		SidecarId: s.SidecarID,
+		RepoTypes: []string{s.Repository.Type},
+	}
+	s.Listener.NetworkAddressFromInterface(d.Get(ListenerKey).([]interface{}))
+
+	s.BindingEnabled = d.Get(BindingEnabledKey).(bool)
+
+	s.ConfAuth = nil
+	if confAuthList := d.Get(ConfAuthKey).([]interface{}); len(confAuthList) > 0 && confAuthList[0] != nil {
+		m := confAuthList[0].(map[string]interface{})
+		s.ConfAuth = &confauth.RepositoryConfAuthData{
+			AllowNativeAuth:  m[confauth.AllowNativeAuthKey].(bool),
+			ClientTLS:        m[confauth.ClientTLSKey].(string),
+			RepoTLS:          m[confauth.RepoTLSKey].(string),
+			IdentityProvider: m[confauth.IdentityProviderKey].(string),
+			AuthType:         m[confauth.AuthTypeKey].(string),
+		}
+	}
+
+	s.ConfAnalysis = nil
+	if confAnalysisList := d.Get(ConfAnalysisKey).([]interface{}); len(confAnalysisList) > 0 && confAnalysisList[0] != nil {
+		m := confAnalysisList[0].(map[string]interface{})
+		s.ConfAnalysis = &confanalysis.UserConfig{
+			Redact:                     m[confanalysis.RedactKey].(string),
+			AlertOnViolation:           m[confanalysis.AlertOnViolationKey].(bool),
+			DisablePreConfiguredAlerts: m[confanalysis.DisablePreConfiguredAlertsKey].(bool),
+			EnableDataMasking:          m[confanalysis.EnableDataMaskingKey].(bool),
+			MaskAllOccurrences:         m[confanalysis.MaskAllOccurrencesKey].(bool),
+			BlockOnViolation:           m[confanalysis.BlockOnViolationKey].(bool),
+			DisableFilterAnalysis:      m[confanalysis.DisableFilterAnalysisKey].(bool),
+			EnableDatasetRewrites:      m[confanalysis.EnableDatasetRewritesKey].(bool),
+			CommentAnnotationGroups:    utils.ConvertFromInterfaceList[string](m[confanalysis.CommentAnnotationGroupsKey].(*schema.Set).List()),
+			LogGroups:                  utils.ConvertFromInterfaceList[string](m[confanalysis.LogGroupsKey].(*schema.Set).List()),
+		}
+	}
+
+	return nil
+}
+
+func confAuthAsInterface(data *confauth.RepositoryConfAuthData) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			confauth.AllowNativeAuthKey:  data.AllowNativeAuth,
+			confauth.ClientTLSKey:        data.ClientTLS,
+			confauth.RepoTLSKey:          data.RepoTLS,
+			confauth.IdentityProviderKey: data.IdentityProvider,
+			confauth.AuthTypeKey:         data.AuthType,
+		},
+	}
+}
+
+func confAnalysisAsInterface(config *confanalysis.UserConfig) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			confanalysis.RedactKey:                     config.Redact,
+			confanalysis.AlertOnViolationKey:           config.AlertOnViolation,
+			confanalysis.DisablePreConfiguredAlertsKey: config.DisablePreConfiguredAlerts,
+			confanalysis.EnableDataMaskingKey:          config.EnableDataMasking,
+			confanalysis.MaskAllOccurrencesKey:         config.MaskAllOccurrences,
+			confanalysis.BlockOnViolationKey:           config.BlockOnViolation,
+			confanalysis.DisableFilterAnalysisKey:      config.DisableFilterAnalysis,
+			confanalysis.EnableDatasetRewritesKey:      config.EnableDatasetRewrites,
+			confanalysis.CommentAnnotationGroupsKey:    utils.ConvertToInterfaceList(config.CommentAnnotationGroups),
+			confanalysis.LogGroupsKey:                  utils.ConvertToInterfaceList(config.LogGroups),
+			confanalysis.EffectiveConfigKey:            config.EffectiveConfigAsInterface(),
+		},
+	}
+}
+
+// doRequest sends a request to the control plane and, if resp is not nil,
+// unmarshals the response body into it.
+func doRequest(ctx context.Context, c *client.Client, method, url string, body, resp interface{}) error {
+	respBody, err := c.DoRequest(ctx, url, method, body)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshalled): %#v", resp))
+	return nil
+}
+
+// deleteIgnoringNotFound deletes the object at the given URL, treating an
+// object that no longer exists as successfully deleted.
+func deleteIgnoringNotFound(ctx context.Context, c *client.Client, url string) error {
+	if err := doRequest(ctx, c, http.MethodDelete, url, nil, nil); err != nil && !client.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func repositoryURL(c *client.Client, repoID string) string {
+	return fmt.Sprintf("https://%s/v1/repos/%s", c.ControlPlane, repoID)
+}
+
+func listenersURL(c *client.Client, sidecarID string) string {
+	return fmt.Sprintf("https://%s/v1/sidecars/%s/listeners", c.ControlPlane, sidecarID)
+}
+
+func bindingsURL(c *client.Client, sidecarID string) string {
+	return fmt.Sprintf("https://%s/v1/sidecars/%s/bindings", c.ControlPlane, sidecarID)
+}
+
+func createRepository(ctx context.Context, c *client.Client, repo *repository.RepoInfo) (string, error) {
+	resp := core.IDBasedResponse{}
+	url := fmt.Sprintf("https://%s/v1/repos", c.ControlPlane)
+	if err := doRequest(ctx, c, http.MethodPost, url, repo, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func updateRepository(ctx context.Context, c *client.Client, repoID string, repo *repository.RepoInfo) error {
+	repo.ID = repoID
+	return doRequest(ctx, c, http.MethodPut, repositoryURL(c, repoID), repo, nil)
+}
+
+func createListener(ctx context.Context, c *client.Client, l *listener.SidecarListener) (string, error) {
+	resp := listener.CreateListenerAPIResponse{}
+	body := listener.SidecarListenerResource{ListenerConfig: *l}
+	if err := doRequest(ctx, c, http.MethodPost, listenersURL(c, l.SidecarId), body, &resp); err != nil {
+		return "", err
+	}
+	return resp.ListenerId, nil
+}
+
+func updateListener(ctx context.Context, c *client.Client, listenerID string, l *listener.SidecarListener) error {
+	l.ListenerId = listenerID
+	body := listener.SidecarListenerResource{ListenerConfig: *l}
+	url := fmt.Sprintf("%s/%s", listenersURL(c, l.SidecarId), listenerID)
+	return doRequest(ctx, c, http.MethodPut, url, body, nil)
+}
+
+func bindingRequest(sidecarID, repoID, listenerID string, enabled bool) *binding.CreateBindingRequest {
+	return &binding.CreateBindingRequest{
+		SidecarID: sidecarID,
+		Binding: &binding.Binding{
+			RepoId:  repoID,
+			Enabled: enabled,
+			ListenerBindings: binding.ListenerBindings{
+				{ListenerID: listenerID, NodeIndex: 0},
+			},
+		},
+	}
+}
+
+func createBinding(ctx context.Context, c *client.Client, req *binding.CreateBindingRequest) (string, error) {
+	resp := binding.CreateBindingResponse{}
+	if err := doRequest(ctx, c, http.MethodPost, bindingsURL(c, req.SidecarID), req, &resp); err != nil {
+		return "", err
+	}
+	return resp.BindingID, nil
+}
+
+func updateBinding(ctx context.Context, c *client.Client, bindingID string, req *binding.CreateBindingRequest) error {
+	req.Binding.BindingID = bindingID
+	url := fmt.Sprintf("%s/%s", bindingsURL(c, req.SidecarID), bindingID)
+	return doRequest(ctx, c, http.MethodPut, url, req, nil)
+}
+
+func putAccessGateway(ctx context.Context, c *client.Client, repoID, sidecarID, bindingID string) error {
+	body := &accessgateway.AccessGateway{
+		AGData: &accessgateway.AGData{
+			SidecarId: sidecarID,
+			BindingId: bindingID,
+		},
+	}
+	url := fmt.Sprintf("%s/accessGateway", repositoryURL(c, repoID))
+	return doRequest(ctx, c, http.MethodPut, url, body, nil)
+}
+
+// writeConfAuth creates or updates the authentication configuration of the
+// repository, the same way the cyral_repository_conf_auth resource does.
+func writeConfAuth(ctx context.Context, c *client.Client, repoID string, data *confauth.RepositoryConfAuthData) error {
+	method := confauth.UpsertMethod(ctx, c, repoID)
+	return doRequest(ctx, c, method, confauth.URL(c, repoID), data, nil)
+}
+
+// writeConfAnalysis creates or updates the analysis configuration of the
+// repository, the same way the cyral_repository_conf_analysis resource does.
+func writeConfAnalysis(ctx context.Context, c *client.Client, repoID string, config *confanalysis.UserConfig) error {
+	method := confanalysis.UpsertMethod(ctx, c, repoID)
+	return doRequest(ctx, c, method, confanalysis.URL(c, repoID), config, nil)
+}
@@ -0,0 +1,472 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/binding"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confanalysis"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confauth"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/listener"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func resourceSchema() *schema.Resource {
+	repoSchema := repository.RepoInfoSchema()
+	repoSchema[repository.RepoTypeKey].ForceNew = true
+	repoSchema[repository.RepoNameKey].ForceNew = true
+
+	return &schema.Resource{
+		Description: "Manages a [repository](https://cyral.com/docs/how-to/track-repos/) together with " +
+			"everything required to access it through a sidecar: the sidecar listener, the repository " +
+			"binding, the access gateway and, optionally, the authentication and analysis " +
+			"configurations." +
+			"\n\nThe objects are created in order. If any step fails, the objects already created by " +
+			"this resource are deleted before the error is reported. Objects that cannot be deleted " +
+			"are kept in the state and the resource is marked as tainted, so that they are removed " +
+			"in the next apply." +
+			"\n\nThis resource must not be combined with the individual resources " +
+			"(`cyral_repository`, `cyral_sidecar_listener`, `cyral_repository_binding`, " +
+			"`cyral_repository_access_gateway`, `cyral_repository_conf_auth` and " +
+			"`cyral_repository_conf_analysis`) for the same repository.",
+		CreateContext: resourceRepositoryStackCreate,
+		ReadContext:   resourceRepositoryStackRead,
+		UpdateContext: resourceRepositoryStackUpdate,
+		DeleteContext: resourceRepositoryStackDelete,
+		CustomizeDiff: resourceRepositoryStackCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateConfAnalysisContradictions,
+		},
+		Schema: map[string]*schema.Schema{
+			utils.IDKey: {
+				Description: "ID of this resource in the Cyral environment. Equal to `" +
+					utils.RepositoryIDKey + "`.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			utils.SidecarIDKey: {
+				Description: "ID of the sidecar the repository will be bound to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			RepositoryKey: {
+				Description: "Repository to be created. Accepts the same arguments as the " +
+					"[`cyral_repository`](./repository.md) resource. Only the first repository node " +
+					"is bound to the sidecar.",
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: repoSchema,
+				},
+			},
+			ListenerKey: {
+				Description: "Network address where the sidecar will listen for the repository. The " +
+					"listener accepts connections for the type of the repository.",
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						utils.HostKey: {
+							Description: "Host where the sidecar will listen for the repository. If omitted, " +
+								"the sidecar will listen on all network interfaces.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						utils.PortKey: {
+							Description:  "Port where the sidecar will listen for the repository.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+			BindingEnabledKey: {
+				Description: "Enable or disable the repository binding. Defaults to `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			ConfAuthKey: {
+				Description: "Authentication configuration of the repository. Accepts the same arguments " +
+					"as the [`cyral_repository_conf_auth`](./repository_conf_auth.md) resource. If " +
+					"omitted, the default configuration created along with the repository is kept.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: confauth.ConfigSchema(),
+				},
+			},
+			ConfAnalysisKey: {
+				Description: "Analysis configuration of the repository. Accepts the same arguments as " +
+					"the [`cyral_repository_conf_analysis`](./repository_conf_analysis.md) resource. If " +
+					"omitted, the default configuration created along with the repository is kept.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: confAnalysisSchema(),
+				},
+			},
+			utils.RepositoryIDKey: {
+				Description: "ID of the repository.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			utils.ListenerIDKey: {
+				Description: "ID of the sidecar listener.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			utils.BindingIDKey: {
+				Description: "ID of the repository binding.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// createStep is a step of the creation of the stack. undo reverts it and
+// is nil for steps whose effects are removed along with the repository.
+type createStep struct {
+	name string
+	undo func(ctx context.Context) error
+}
+
+func resourceRepositoryStackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryStackCreate")
+	c := m.(*client.Client)
+
+	stack := &Stack{}
+	if err := stack.ReadFromSchema(d); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to create %s", resourceName), err.Error())
+	}
+
+	var done []createStep
+	fail := func(step string, err error) diag.Diagnostics {
+		diags := utils.CreateError(fmt.Sprintf("Unable to create %s", resourceName),
+			fmt.Sprintf("unable to %s: %v", step, err))
+		return append(diags, rollback(ctx, d, done)...)
+	}
+
+	repoID, err := createRepository(ctx, c, stack.Repository)
+	if err != nil {
+		return fail("create repository", err)
+	}
+	d.SetId(repoID)
+	d.Set(utils.RepositoryIDKey, repoID)
+	done = append(done, createStep{
+		name: "repository",
+		undo: func(ctx context.Context) error {
+			if err := deleteIgnoringNotFound(ctx, c, repositoryURL(c, repoID)); err != nil {
+				return err
+			}
+			d.SetId("")
+			d.Set(utils.RepositoryIDKey, "")
+			return nil
+		},
+	})
+
+	listenerID, err := createListener(ctx, c, stack.Listener)
+	if err != nil {
+		return fail("create sidecar listener", err)
+	}
+	d.Set(utils.ListenerIDKey, listenerID)
+	done = append(done, createStep{
+		name: "sidecar listener",
+		undo: func(ctx context.Context) error {
+			url := fmt.Sprintf("%s/%s", listenersURL(c, stack.SidecarID), listenerID)
+			if err := deleteIgnoringNotFound(ctx, c, url); err != nil {
+				return err
+			}
+			d.Set(utils.ListenerIDKey, "")
+			return nil
+		},
+	})
+
+	bindingID, err := createBinding(ctx, c,
+		bindingRequest(stack.SidecarID, repoID, listenerID, stack.BindingEnabled))
+	if err != nil {
+		return fail("create repository binding", err)
+	}
+	d.Set(utils.BindingIDKey, bindingID)
+	done = append(done, createStep{
+		name: "repository binding",
+		undo: func(ctx context.Context) error {
+			url := fmt.Sprintf("%s/%s", bindingsURL(c, stack.SidecarID), bindingID)
+			if err := deleteIgnoringNotFound(ctx, c, url); err != nil {
+				return err
+			}
+			d.Set(utils.BindingIDKey, "")
+			return nil
+		},
+	})
+
+	if err := putAccessGateway(ctx, c, repoID, stack.SidecarID, bindingID); err != nil {
+		return fail("set access gateway", err)
+	}
+	done = append(done, createStep{
+		name: "access gateway",
+		undo: func(ctx context.Context) error {
+			return deleteIgnoringNotFound(ctx, c, fmt.Sprintf("%s/accessGateway", repositoryURL(c, repoID)))
+		},
+	})
+
+	if stack.ConfAuth != nil {
+		if err := writeConfAuth(ctx, c, repoID, stack.ConfAuth); err != nil {
+			return fail("set authentication configuration", err)
+		}
+	}
+	if stack.ConfAnalysis != nil {
+		if err := writeConfAnalysis(ctx, c, repoID, stack.ConfAnalysis); err != nil {
+			return fail("set analysis configuration", err)
+		}
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryStackCreate")
+	return resourceRepositoryStackRead(ctx, d, m)
+}
+
+// rollback reverts the given steps in reverse order. It stops at the first
+// step that cannot be reverted, so that the repository is always deleted
+// last and the remaining objects can still be found from the state.
+func rollback(ctx context.Context, d *schema.ResourceData, done []createStep) diag.Diagnostics {
+	for i := len(done) - 1; i >= 0; i-- {
+		step := done[i]
+		if step.undo == nil {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Rolling back %s", step.name))
+		if err := step.undo(ctx); err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to roll back %s of %s", step.name, resourceName),
+				Detail: fmt.Sprintf("%v. The objects that could not be deleted are kept in the "+
+					"state and will be deleted in the next apply.", err),
+			}}
+		}
+	}
+	return nil
+}
+
+func resourceRepositoryStackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryStackRead")
+	c := m.(*client.Client)
+	readError := func(err error) diag.Diagnostics {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+	}
+
+	repoID := d.Id()
+	sidecarID := d.Get(utils.SidecarIDKey).(string)
+
+	repoResp := repository.GetRepoByIDResponse{}
+	if err := doRequest(ctx, c, http.MethodGet, repositoryURL(c, repoID), nil, &repoResp); err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, fmt.Sprintf("Repository %s not found, removing %s from state.", repoID, resourceName))
+			d.SetId("")
+			return nil
+		}
+		return readError(err)
+	}
+	if err := d.Set(RepositoryKey, []interface{}{repoResp.Repo.AsMap()}); err != nil {
+		return readError(fmt.Errorf(utils.ErrorSettingFieldFmt, RepositoryKey, err))
+	}
+	d.Set(utils.RepositoryIDKey, repoID)
+
+	// Missing children have their IDs cleared, which makes
+	// resourceRepositoryStackCustomizeDiff plan their recreation.
+	if listenerID := d.Get(utils.ListenerIDKey).(string); listenerID != "" {
+		listenerResp := listener.ReadSidecarListenerAPIResponse{}
+		url := fmt.Sprintf("%s/%s", listenersURL(c, sidecarID), listenerID)
+		if err := doRequest(ctx, c, http.MethodGet, url, nil, &listenerResp); err != nil {
+			if !client.IsNotFound(err) {
+				return readError(err)
+			}
+			d.Set(utils.ListenerIDKey, "")
+		} else if listenerResp.ListenerConfig != nil {
+			if err := d.Set(ListenerKey, listenerResp.ListenerConfig.NetworkAddressAsInterface()); err != nil {
+				return readError(fmt.Errorf(utils.ErrorSettingFieldFmt, ListenerKey, err))
+			}
+		}
+	}
+
+	if bindingID := d.Get(utils.BindingIDKey).(string); bindingID != "" {
+		bindingResp := binding.GetBindingResponse{}
+		url := fmt.Sprintf("%s/%s", bindingsURL(c, sidecarID), bindingID)
+		if err := doRequest(ctx, c, http.MethodGet, url, nil, &bindingResp); err != nil {
+			if !client.IsNotFound(err) {
+				return readError(err)
+			}
+			d.Set(utils.BindingIDKey, "")
+		} else if bindingResp.Binding != nil {
+			d.Set(BindingEnabledKey, bindingResp.Binding.Enabled)
+		}
+	}
+
+	confAuthResp := confauth.ReadRepositoryConfAuthResponse{}
+	url := confauth.URL(c, repoID)
+	if err := doRequest(ctx, c, http.MethodGet, url, nil, &confAuthResp); err != nil {
+		if !client.IsNotFound(err) {
+			return readError(err)
+		}
+	} else if err := d.Set(ConfAuthKey, confAuthAsInterface(&confAuthResp.AuthInfo)); err != nil {
+		return readError(fmt.Errorf(utils.ErrorSettingFieldFmt, ConfAuthKey, err))
+	}
+
+	confAnalysisResp := confanalysis.RepositoryConfAnalysisData{}
+	url = confanalysis.URL(c, repoID)
+	if err := doRequest(ctx, c, http.MethodGet, url, nil, &confAnalysisResp); err != nil {
+		if !client.IsNotFound(err) {
+			return readError(err)
+		}
+	} else if err := d.Set(ConfAnalysisKey, confAnalysisAsInterface(&confAnalysisResp.UserConfig)); err != nil {
+		return readError(fmt.Errorf(utils.ErrorSettingFieldFmt, ConfAnalysisKey, err))
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryStackRead")
+	return nil
+}
+
+func resourceRepositoryStackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryStackUpdate")
+	c := m.(*client.Client)
+	updateError := func(step string, err error) diag.Diagnostics {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName),
+			fmt.Sprintf("unable to %s: %v", step, err))
+	}
+
+	stack := &Stack{}
+	if err := stack.ReadFromSchema(d); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName), err.Error())
+	}
+	repoID := d.Id()
+
+	if d.HasChange(RepositoryKey) {
+		if err := updateRepository(ctx, c, repoID, stack.Repository); err != nil {
+			return updateError("update repository", err)
+		}
+	}
+
+	// Children that were deleted outside of Terraform are recreated, and
+	// the objects that reference them are updated accordingly.
+	listenerID := d.Get(utils.ListenerIDKey).(string)
+	listenerRecreated := false
+	if listenerID == "" {
+		newID, err := createListener(ctx, c, stack.Listener)
+		if err != nil {
+			return updateError("create sidecar listener", err)
+		}
+		listenerID, listenerRecreated = newID, true
+		d.Set(utils.ListenerIDKey, listenerID)
+	} else if d.HasChange(ListenerKey) {
+		if err := updateListener(ctx, c, listenerID, stack.Listener); err != nil {
+			return updateError("update sidecar listener", err)
+		}
+	}
+
+	bindingID := d.Get(utils.BindingIDKey).(string)
+	req := bindingRequest(stack.SidecarID, repoID, listenerID, stack.BindingEnabled)
+	bindingRecreated := false
+	if bindingID == "" {
+		newID, err := createBinding(ctx, c, req)
+		if err != nil {
+			return updateError("create repository binding", err)
+		}
+		bindingID, bindingRecreated = newID, true
+		d.Set(utils.BindingIDKey, bindingID)
+	} else if listenerRecreated || d.HasChange(BindingEnabledKey) {
+		if err := updateBinding(ctx, c, bindingID, req); err != nil {
+			return updateError("update repository binding", err)
+		}
+	}
+
+	if bindingRecreated {
+		if err := putAccessGateway(ctx, c, repoID, stack.SidecarID, bindingID); err != nil {
+			return updateError("set access gateway", err)
+		}
+	}
+
+	if stack.ConfAuth != nil && d.HasChange(ConfAuthKey) {
+		if err := writeConfAuth(ctx, c, repoID, stack.ConfAuth); err != nil {
+			return updateError("set authentication configuration", err)
+		}
+	}
+	if stack.ConfAnalysis != nil && d.HasChange(ConfAnalysisKey) {
+		if err := writeConfAnalysis(ctx, c, repoID, stack.ConfAnalysis); err != nil {
+			return updateError("set analysis configuration", err)
+		}
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryStackUpdate")
+	return resourceRepositoryStackRead(ctx, d, m)
+}
+
+func resourceRepositoryStackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceRepositoryStackDelete")
+	c := m.(*client.Client)
+	deleteError := func(step string, err error) diag.Diagnostics {
+		return utils.CreateError(fmt.Sprintf("Unable to delete %s", resourceName),
+			fmt.Sprintf("unable to delete %s: %v", step, err))
+	}
+
+	repoID := d.Id()
+	sidecarID := d.Get(utils.SidecarIDKey).(string)
+
+	// Each ID is cleared once the corresponding object is deleted, so that a
+	// failed deletion can be retried from where it stopped.
+	if err := deleteIgnoringNotFound(ctx, c, fmt.Sprintf("%s/accessGateway", repositoryURL(c, repoID))); err != nil {
+		return deleteError("access gateway", err)
+	}
+	if bindingID := d.Get(utils.BindingIDKey).(string); bindingID != "" {
+		url := fmt.Sprintf("%s/%s", bindingsURL(c, sidecarID), bindingID)
+		if err := deleteIgnoringNotFound(ctx, c, url); err != nil {
+			return deleteError("repository binding", err)
+		}
+		d.Set(utils.BindingIDKey, "")
+	}
+	if listenerID := d.Get(utils.ListenerIDKey).(string); listenerID != "" {
+		url := fmt.Sprintf("%s/%s", listenersURL(c, sidecarID), listenerID)
+		if err := deleteIgnoringNotFound(ctx, c, url); err != nil {
+			return deleteError("sidecar listener", err)
+		}
+		d.Set(utils.ListenerIDKey, "")
+	}
+	if err := deleteIgnoringNotFound(ctx, c, repositoryURL(c, repoID)); err != nil {
+		return deleteError("repository", err)
+	}
+
+	tflog.Debug(ctx, "End resourceRepositoryStackDelete")
+	return nil
+}
+
+// resourceRepositoryStackCustomizeDiff plans the recreation of the listener
+// and the binding when they were found missing during the refresh.
+func resourceRepositoryStackCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, key := range []string{utils.ListenerIDKey, utils.BindingIDKey} {
+		if d.Get(key).(string) == "" {
+			tflog.Debug(ctx, fmt.Sprintf("%s is empty, planning its recreation", key))
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
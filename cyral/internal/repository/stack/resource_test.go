@@ -0,0 +1,81 @@
+package stack_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	repositoryStackResourceName = "repository-stack"
+)
+
+func TestAccRepositoryStackResource(t *testing.T) {
+	resourceFullName := "cyral_repository_stack.test"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryStackConfig("pg.local", 5432, true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceFullName, "id", resourceFullName, "repository_id"),
+					resource.TestMatchResourceAttr(resourceFullName, "listener_id", utils.NotZeroRegex()),
+					resource.TestMatchResourceAttr(resourceFullName, "binding_id", utils.NotZeroRegex()),
+					resource.TestCheckResourceAttr(resourceFullName, "repository.0.repo_node.0.host", "pg.local"),
+					resource.TestCheckResourceAttr(resourceFullName, "listener.0.port", "5432"),
+					resource.TestCheckResourceAttr(resourceFullName, "binding_enabled", "true"),
+				),
+			},
+			{
+				Config: repositoryStackConfig("pg-new.local", 5433, false, `
+				conf_auth {
+					client_tls = "enable"
+					repo_tls   = "enable"
+				}
+				conf_analysis {
+					redact     = "watched"
+					log_groups = ["dql", "dml"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceFullName, "repository.0.repo_node.0.host", "pg-new.local"),
+					resource.TestCheckResourceAttr(resourceFullName, "listener.0.port", "5433"),
+					resource.TestCheckResourceAttr(resourceFullName, "binding_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceFullName, "conf_auth.0.client_tls", "enable"),
+					resource.TestCheckResourceAttr(resourceFullName, "conf_auth.0.repo_tls", "enable"),
+					resource.TestCheckResourceAttr(resourceFullName, "conf_analysis.0.redact", "watched"),
+					resource.TestCheckResourceAttr(resourceFullName, "conf_analysis.0.log_groups.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func repositoryStackConfig(host string, port int, bindingEnabled bool, extra string) string {
+	config := utils.FormatBasicSidecarIntoConfig(
+		utils.BasicSidecarResName,
+		utils.AccTestName(repositoryStackResourceName, "sidecar"),
+		"docker", "",
+	)
+	config += fmt.Sprintf(`
+	resource "cyral_repository_stack" "test" {
+		sidecar_id = %s
+		repository {
+			type = "postgresql"
+			name = "%s"
+			repo_node {
+				host = "%s"
+				port = 5432
+			}
+		}
+		listener {
+			port = %d
+		}
+		binding_enabled = %t
+		%s
+	}`, utils.BasicSidecarID, utils.AccTestName(repositoryStackResourceName, "repo"),
+		host, port, bindingEnabled, extra)
+	return config
+}
@@ -0,0 +1,75 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		failingStep   string
+		expectedUndos []string
+		expectError   bool
+	}{
+		{
+			desc:          "all steps are reverted in reverse order",
+			expectedUndos: []string{"access gateway", "repository binding", "sidecar listener", "repository"},
+		},
+		{
+			desc:          "rollback stops at the first step that cannot be reverted",
+			failingStep:   "sidecar listener",
+			expectedUndos: []string{"access gateway", "repository binding", "sidecar listener"},
+			expectError:   true,
+		},
+		{
+			desc:          "repository is not deleted when the last step cannot be reverted",
+			failingStep:   "access gateway",
+			expectedUndos: []string{"access gateway"},
+			expectError:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var undone []string
+			step := func(name string) createStep {
+				return createStep{
+					name: name,
+					undo: func(_ context.Context) error {
+						undone = append(undone, name)
+						if name == testCase.failingStep {
+							return errors.New("request failed")
+						}
+						return nil
+					},
+				}
+			}
+			done := []createStep{
+				step("repository"),
+				step("sidecar listener"),
+				// Steps without undo function are skipped.
+				{name: "authentication configuration"},
+				step("repository binding"),
+				step("access gateway"),
+			}
+
+			diags := rollback(context.Background(), nil, done)
+
+			assert.Equal(t, testCase.expectedUndos, undone)
+			if !testCase.expectError {
+				assert.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			assert.Equal(t, diag.Error, diags[0].Severity)
+			assert.Contains(t, diags[0].Summary, testCase.failingStep)
+			assert.Contains(t, diags[0].Detail, "request failed")
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package useraccount
 
 const (
-	resourceName = "cyral_repository_user_account"
+	resourceName   = "cyral_repository_user_account"
+	dataSourceName = "cyral_repository_user_account"
+)
+
+const (
+	// Data source keys.
+	AuthSchemeTypeKey  = "auth_scheme_type"
+	UserAccountListKey = "user_account_list"
 )
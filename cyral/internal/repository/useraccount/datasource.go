@@ -0,0 +1,135 @@
+package useraccount
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+type ListUserAccountsResponse struct {
+	UserAccounts []*UserAccountResource `json:"userAccounts"`
+}
+
+// ListUserAccounts retrieves all the user accounts of the repository.
+func ListUserAccounts(ctx context.Context, c *client.Client, repoID string) ([]*UserAccountResource, error) {
+	tflog.Debug(ctx, "Init ListUserAccounts")
+	url := fmt.Sprintf("https://%s/v1/repos/%s/userAccounts", c.ControlPlane, repoID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := ListUserAccountsResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Found %d user accounts", len(resp.UserAccounts)))
+	tflog.Debug(ctx, "End ListUserAccounts")
+	return resp.UserAccounts, nil
+}
+
+func dataSourceSchema() *schema.Resource {
+	userAccountSchema := resourceSchema().Schema
+	delete(userAccountSchema, utils.IDKey)
+	delete(userAccountSchema, utils.RepositoryIDKey)
+	userAccountSchema = utils.ConvertSchemaFieldsToComputed(userAccountSchema)
+	// ConvertSchemaFieldsToComputed drops the Sensitive flag.
+	userAccountSchema["auth_scheme"].Elem.(*schema.Resource).
+		Schema["cyral_storage"].Elem.(*schema.Resource).
+		Schema["password"].Sensitive = true
+	userAccountSchema[AuthSchemeTypeKey] = &schema.Schema{
+		Description: "Type of the auth scheme of the user account, i.e. the name of the block set in `auth_scheme`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves the user accounts of a repository, including the ones that are not " +
+			"managed by this Terraform configuration. See [`" + UserAccountListKey + "`](#nestedatt--" +
+			UserAccountListKey + ").",
+		ReadContext: dataSourceRepositoryUserAccountRead,
+		Schema: map[string]*schema.Schema{
+			utils.RepositoryIDKey: {
+				Description: "ID of the repository.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			utils.NameKey: {
+				Description:  "Filter the results by a regular expression (regex) that matches names of existing user accounts.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			AuthSchemeTypeKey: {
+				Description: "Filter the results by type of auth scheme. List of supported types:" +
+					utils.SupportedValuesAsMarkdown(allAuthSchemes),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(allAuthSchemes, false),
+			},
+			UserAccountListKey: {
+				Description: "List of existing user accounts satisfying the filter criteria.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: userAccountSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepositoryUserAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceRepositoryUserAccountRead")
+	c := m.(*client.Client)
+	repoID := d.Get(utils.RepositoryIDKey).(string)
+
+	var nameRegex *regexp.Regexp
+	if nameFilter := d.Get(utils.NameKey).(string); nameFilter != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(nameFilter); err != nil {
+			return utils.CreateError("Invalid name filter", err.Error())
+		}
+	}
+	authSchemeTypeFilter := d.Get(AuthSchemeTypeKey).(string)
+
+	userAccounts, err := ListUserAccounts(ctx, c, repoID)
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to retrieve the user accounts of repository %q", repoID), err.Error())
+	}
+
+	userAccountList := make([]interface{}, 0, len(userAccounts))
+	for _, userAccount := range userAccounts {
+		if nameRegex != nil && !nameRegex.MatchString(userAccount.Name) {
+			continue
+		}
+		authSchemeType := userAccount.AuthSchemeType()
+		if authSchemeTypeFilter != "" && authSchemeType != authSchemeTypeFilter {
+			continue
+		}
+		userAccountMap, err := userAccount.AsMap()
+		if err != nil {
+			return utils.CreateError("Unable to read user accounts", err.Error())
+		}
+		userAccountMap[AuthSchemeTypeKey] = authSchemeType
+		userAccountList = append(userAccountList, userAccountMap)
+	}
+
+	if err := d.Set(UserAccountListKey, userAccountList); err != nil {
+		return utils.CreateError("Unable to read user accounts",
+			fmt.Errorf(utils.ErrorSettingFieldFmt, UserAccountListKey, err).Error())
+	}
+	d.SetId(repoID)
+
+	tflog.Debug(ctx, "End dataSourceRepositoryUserAccountRead")
+	return nil
+}
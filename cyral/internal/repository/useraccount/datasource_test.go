@@ -0,0 +1,102 @@
+package useraccount_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	repositoryUserAccountDataSourceName = "data-repository-user-account"
+)
+
+func TestAccRepositoryUserAccountDataSource(t *testing.T) {
+	config := utils.FormatBasicRepositoryIntoConfig(
+		utils.BasicRepositoryResName,
+		utils.AccTestName(repositoryUserAccountDataSourceName, "repo"),
+		"mongodb",
+		"mongodb.local",
+		27017,
+	)
+	config += fmt.Sprintf(`
+	resource "cyral_repository_user_account" "env" {
+		repository_id = %[1]s
+		name          = "reader-env"
+		auth_scheme {
+			environment_variable {
+				variable_name = "CYRAL_READER"
+			}
+		}
+	}
+
+	resource "cyral_repository_user_account" "gcp" {
+		repository_id = %[1]s
+		name          = "reader-gcp"
+		approval_config {
+			automatic_grant         = true
+			max_auto_grant_duration = "1234s"
+		}
+		auth_scheme {
+			gcp_secrets_manager {
+				secret_name = "projects/my-project/secrets/reader/versions/1"
+			}
+		}
+	}
+
+	resource "cyral_repository_user_account" "admin" {
+		repository_id = %[1]s
+		name          = "admin"
+		auth_scheme {
+			environment_variable {
+				variable_name = "CYRAL_ADMIN"
+			}
+		}
+	}
+
+	data "cyral_repository_user_account" "readers" {
+		repository_id = %[1]s
+		name          = "^reader-"
+		depends_on = [
+			cyral_repository_user_account.env,
+			cyral_repository_user_account.gcp,
+			cyral_repository_user_account.admin,
+		]
+	}
+
+	data "cyral_repository_user_account" "gcp" {
+		repository_id    = %[1]s
+		auth_scheme_type = "gcp_secrets_manager"
+		depends_on = [
+			cyral_repository_user_account.env,
+			cyral_repository_user_account.gcp,
+			cyral_repository_user_account.admin,
+		]
+	}`, utils.BasicRepositoryID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cyral_repository_user_account.readers",
+						"user_account_list.#", "2"),
+					resource.TestCheckResourceAttr("data.cyral_repository_user_account.gcp",
+						"user_account_list.#", "1"),
+					resource.TestCheckResourceAttr("data.cyral_repository_user_account.gcp",
+						"user_account_list.0.name", "reader-gcp"),
+					resource.TestCheckResourceAttr("data.cyral_repository_user_account.gcp",
+						"user_account_list.0.auth_scheme_type", "gcp_secrets_manager"),
+					resource.TestCheckResourceAttr("data.cyral_repository_user_account.gcp",
+						"user_account_list.0.approval_config.0.max_auto_grant_duration", "1234s"),
+					resource.TestCheckResourceAttrPair(
+						"data.cyral_repository_user_account.gcp", "user_account_list.0.user_account_id",
+						"cyral_repository_user_account.gcp", "user_account_id"),
+				),
+			},
+		},
+	})
+}
@@ -17,6 +17,8 @@ type AuthScheme struct {
 	KubernetesSecret    *AuthSchemeKubernetesSecret    `json:"kubernetesSecret"`
 	GCPSecretManager    *AuthSchemeGCPSecretManager    `json:"gcpSecretManager"`
 	AzureKeyVault       *AuthSchemeAzureKeyVault       `json:"azureKeyVault"`
+	CyberArkConjur      *AuthSchemeCyberArkConjur      `json:"cyberarkConjur"`
+	DelineaSecretServer *AuthSchemeDelineaSecretServer `json:"delineaSecretServer"`
 }
 
 type AuthSchemeAWSIAM struct {
@@ -54,6 +56,17 @@ type AuthSchemeAzureKeyVault struct {
 	SecretURL string `json:"secretUrl,omitempty"`
 }
 
+type AuthSchemeCyberArkConjur struct {
+	Account    string `json:"account,omitempty"`
+	VariableID string `json:"variableId,omitempty"`
+}
+
+type AuthSchemeDelineaSecretServer struct {
+	SecretID      int    `json:"secretId,omitempty"`
+	UsernameField string `json:"usernameField,omitempty"`
+	PasswordField string `json:"passwordField,omitempty"`
+}
+
 type ApprovalConfig struct {
 	AutomaticGrant            bool   `json:"automaticGrant,omitempty"`
 	MaxAutomaticGrantDuration string `json:"maxAutomaticGrantDuration,omitempty"`
@@ -98,20 +111,69 @@ func (resource *UserAccountResource) WriteToSchema(d *schema.ResourceData) error
 		return fmt.Errorf("error setting 'auth_database_name': %w", err)
 	}
 
-	if resource.Config != nil {
-		if resource.Config.Approval != nil {
-			err := d.Set("approval_config", []interface{}{
-				map[string]interface{}{
-					"automatic_grant":         resource.Config.Approval.AutomaticGrant,
-					"max_auto_grant_duration": resource.Config.Approval.MaxAutomaticGrantDuration,
-				},
-			})
-			if err != nil {
-				return fmt.Errorf("error setting 'approval_config': %w", err)
-			}
+	if approvalConfig := resource.approvalConfigAsInterface(); approvalConfig != nil {
+		if err := d.Set("approval_config", approvalConfig); err != nil {
+			return fmt.Errorf("error setting 'approval_config': %w", err)
 		}
 	}
 
+	authScheme, err := resource.authSchemeAsInterface()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("auth_scheme", authScheme); err != nil {
+		return fmt.Errorf("error setting 'auth_scheme': %w", err)
+	}
+
+	return nil
+}
+
+// AsMap returns the user account attributes, as stored in the Terraform
+// state.
+func (resource *UserAccountResource) AsMap() (map[string]interface{}, error) {
+	authScheme, err := resource.authSchemeAsInterface()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"user_account_id":    resource.UserAccountID,
+		"name":               resource.Name,
+		"auth_database_name": resource.AuthDatabaseName,
+		"approval_config":    resource.approvalConfigAsInterface(),
+		"auth_scheme":        authScheme,
+	}, nil
+}
+
+func (resource *UserAccountResource) approvalConfigAsInterface() []interface{} {
+	if resource.Config == nil || resource.Config.Approval == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"automatic_grant":         resource.Config.Approval.AutomaticGrant,
+			"max_auto_grant_duration": resource.Config.Approval.MaxAutomaticGrantDuration,
+		},
+	}
+}
+
+// AuthSchemeType returns the name of the auth scheme block used by the
+// user account, or an empty string if it has no auth scheme.
+func (resource *UserAccountResource) AuthSchemeType() string {
+	authScheme, err := resource.authSchemeAsInterface()
+	if err != nil {
+		return ""
+	}
+	for authSchemeType := range authScheme[0].(map[string]interface{}) {
+		return authSchemeType
+	}
+	return ""
+}
+
+func (resource *UserAccountResource) authSchemeAsInterface() ([]interface{}, error) {
+	if resource.AuthScheme == nil {
+		return nil, fmt.Errorf("auth scheme is required, user account is corrupt: %v", resource)
+	}
+
 	var authScheme []interface{}
 	switch {
 	case resource.AuthScheme.AWSIAM != nil:
@@ -197,15 +259,34 @@ func (resource *UserAccountResource) WriteToSchema(d *schema.ResourceData) error
 				},
 			},
 		}
+	case resource.AuthScheme.CyberArkConjur != nil:
+		authScheme = []interface{}{
+			map[string]interface{}{
+				"cyberark_conjur": []interface{}{
+					map[string]interface{}{
+						"account":     resource.AuthScheme.CyberArkConjur.Account,
+						"variable_id": resource.AuthScheme.CyberArkConjur.VariableID,
+					},
+				},
+			},
+		}
+	case resource.AuthScheme.DelineaSecretServer != nil:
+		authScheme = []interface{}{
+			map[string]interface{}{
+				"delinea_secret_server": []interface{}{
+					map[string]interface{}{
+						"secret_id":      resource.AuthScheme.DelineaSecretServer.SecretID,
+						"username_field": resource.AuthScheme.DelineaSecretServer.UsernameField,
+						"password_field": resource.AuthScheme.DelineaSecretServer.PasswordField,
+					},
+				},
+			},
+		}
 	default:
-		return fmt.Errorf("auth scheme is required, user account is corrupt: %v", resource)
+		return nil, fmt.Errorf("auth scheme is required, user account is corrupt: %v", resource)
 	}
 
-	if err := d.Set("auth_scheme", authScheme); err != nil {
-		return fmt.Errorf("error setting 'auth_scheme': %w", err)
-	}
-
-	return nil
+	return authScheme, nil
 }
 
 // ReadFromSchema is used to translate a .tf file into whatever the
@@ -303,6 +384,21 @@ func (userAccount *UserAccountResource) ReadFromSchema(d *schema.ResourceData) e
 					SecretURL: m["secret_url"].(string),
 				},
 			}
+		case "cyberark_conjur":
+			userAccount.AuthScheme = &AuthScheme{
+				CyberArkConjur: &AuthSchemeCyberArkConjur{
+					Account:    m["account"].(string),
+					VariableID: m["variable_id"].(string),
+				},
+			}
+		case "delinea_secret_server":
+			userAccount.AuthScheme = &AuthScheme{
+				DelineaSecretServer: &AuthSchemeDelineaSecretServer{
+					SecretID:      m["secret_id"].(int),
+					UsernameField: m["username_field"].(string),
+					PasswordField: m["password_field"].(string),
+				},
+			}
 		default:
 			return fmt.Errorf("unexpected auth_scheme [%s]", k)
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var allAuthSchemes = []string{
@@ -22,6 +23,8 @@ var allAuthSchemes = []string{
 	"kubernetes_secret",
 	"gcp_secrets_manager",
 	"azure_key_vault",
+	"cyberark_conjur",
+	"delinea_secret_server",
 }
 
 var urlFactory = func(d *schema.ResourceData, c *client.Client) string {
@@ -208,9 +211,11 @@ func resourceSchema() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"role_arn": {
-										Description: "The AWS IAM roleARN to gain access to the database.",
-										Type:        schema.TypeString,
-										Required:    true,
+										Description: "The AWS IAM roleARN to gain access to the database, " +
+											"such as `arn:aws:iam::123456789012:role/my-role`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: warnOnInvalidFormat(validateAWSIAMRoleARN()),
 									},
 									"authenticate_as_iam_role": {
 										Description: "Indicates whether to access as an AWS IAM role (`true`)" +
@@ -232,9 +237,11 @@ func resourceSchema() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"secret_arn": {
-										Description: "The AWS Secrets Manager secretARN to gain access to the database.",
-										Type:        schema.TypeString,
-										Required:    true,
+										Description: "The AWS Secrets Manager secretARN to gain access to the database, " +
+											"such as `arn:aws:secretsmanager:us-east-1:123456789012:secret:my-secret`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: warnOnInvalidFormat(utils.ValidationAWSSecretARN()),
 									},
 								},
 							},
@@ -270,9 +277,10 @@ func resourceSchema() *schema.Resource {
 								Schema: map[string]*schema.Schema{
 									"path": {
 										Description: "The location in the Vault where the database username and" +
-											" password may be retrieved.",
-										Type:     schema.TypeString,
-										Required: true,
+											" password may be retrieved, such as `database/creds/my-role`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: warnOnInvalidFormat(validateVaultPath()),
 									},
 									"is_dynamic_user_account": {
 										Description: "Some Vault engines allow the dynamic creation of user accounts," +
@@ -317,9 +325,12 @@ func resourceSchema() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"secret_name": {
-										Description: "The unique identifier of the secret in GCP Secrets Manager.",
-										Type:        schema.TypeString,
-										Required:    true,
+										Description: "The resource name of the secret in GCP Secrets Manager, " +
+											"such as `projects/my-project/secrets/my-secret`, optionally followed by " +
+											"`/versions/{version}`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: warnOnInvalidFormat(validateGCPSecretName()),
 									},
 								},
 							},
@@ -335,9 +346,70 @@ func resourceSchema() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"secret_url": {
-										Description: "The URL of the secret in the Azure Key Vault.",
-										Type:        schema.TypeString,
-										Required:    true,
+										Description: "The URL of the secret in the Azure Key Vault, such as " +
+											"`https://my-vault.vault.azure.net/secrets/my-secret`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: warnOnInvalidFormat(validateAzureKeyVaultSecretURL()),
+									},
+								},
+							},
+						},
+
+						"cyberark_conjur": {
+							Description: "Credential option to set the repository user account from " +
+								"CyberArk Conjur.",
+							Optional:     true,
+							Type:         schema.TypeSet,
+							ExactlyOneOf: authSchemeTypesFullScopes,
+							MaxItems:     1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"variable_id": {
+										Description: "The ID of the Conjur variable that stores the credentials, " +
+											"such as `my-app/db/password`.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateConjurVariableID(),
+									},
+									"account": {
+										Description: "The Conjur account that owns the variable. Defaults to the " +
+											"account configured in the sidecar.",
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"delinea_secret_server": {
+							Description: "Credential option to set the repository user account from " +
+								"Delinea Secret Server.",
+							Optional:     true,
+							Type:         schema.TypeSet,
+							ExactlyOneOf: authSchemeTypesFullScopes,
+							MaxItems:     1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"secret_id": {
+										Description:  "The ID of the secret in Delinea Secret Server.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"username_field": {
+										Description: "The slug of the secret field that stores the username. " +
+											"Defaults to `username`.",
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "username",
+									},
+									"password_field": {
+										Description: "The slug of the secret field that stores the password. " +
+											"Defaults to `password`.",
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "password",
 									},
 								},
 							},
@@ -160,6 +160,25 @@ func TestAccRepositoryUserAccountResource(t *testing.T) {
 			},
 		},
 	}
+	cyberArkConjur := useraccount.UserAccountResource{
+		Name: "conjur-useracc",
+		AuthScheme: &useraccount.AuthScheme{
+			CyberArkConjur: &useraccount.AuthSchemeCyberArkConjur{
+				Account:    "account-1",
+				VariableID: "app-1/db/password",
+			},
+		},
+	}
+	delineaSecretServer := useraccount.UserAccountResource{
+		Name: "delinea-useracc",
+		AuthScheme: &useraccount.AuthScheme{
+			DelineaSecretServer: &useraccount.AuthSchemeDelineaSecretServer{
+				SecretID:      42,
+				UsernameField: "login",
+				PasswordField: "password",
+			},
+		},
+	}
 	awsIAMTest := setupRepositoryUserAccountTest(
 		"aws_iam_test", awsIAM)
 	awsSecretsManagerTest := setupRepositoryUserAccountTest(
@@ -176,6 +195,10 @@ func TestAccRepositoryUserAccountResource(t *testing.T) {
 		"gcp_secret_manager_test", gcpSecretManager)
 	azureKeyVaultTest := setupRepositoryUserAccountTest(
 		"azure_key_vault_test", azureKeyVault)
+	cyberArkConjurTest := setupRepositoryUserAccountTest(
+		"cyberark_conjur_test", cyberArkConjur)
+	delineaSecretServerTest := setupRepositoryUserAccountTest(
+		"delinea_secret_server_test", delineaSecretServer)
 
 	// Test with multiple user accounts
 	userAccount1ResName := "multiple_accounts_test_1"
@@ -228,6 +251,8 @@ func TestAccRepositoryUserAccountResource(t *testing.T) {
 			kubernetesSecretTest,
 			gcpSecretManagerTest,
 			azureKeyVaultTest,
+			cyberArkConjurTest,
+			delineaSecretServerTest,
 
 			// Test with multiple user accounts
 			multipleAccountsTest,
@@ -339,6 +364,32 @@ func setupRepositoryUserAccountCheck(resName string, userAccount useraccount.Use
 				strconv.FormatBool(authScheme.HashicorpVault.IsDynamicUserAccount),
 			),
 		}...)
+	case authScheme.CyberArkConjur != nil:
+		checkFuncs = append(checkFuncs, []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(resFullName,
+				authSchemeScope+"cyberark_conjur.0.account",
+				authScheme.CyberArkConjur.Account,
+			),
+			resource.TestCheckResourceAttr(resFullName,
+				authSchemeScope+"cyberark_conjur.0.variable_id",
+				authScheme.CyberArkConjur.VariableID,
+			),
+		}...)
+	case authScheme.DelineaSecretServer != nil:
+		checkFuncs = append(checkFuncs, []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr(resFullName,
+				authSchemeScope+"delinea_secret_server.0.secret_id",
+				strconv.Itoa(authScheme.DelineaSecretServer.SecretID),
+			),
+			resource.TestCheckResourceAttr(resFullName,
+				authSchemeScope+"delinea_secret_server.0.username_field",
+				authScheme.DelineaSecretServer.UsernameField,
+			),
+			resource.TestCheckResourceAttr(resFullName,
+				authSchemeScope+"delinea_secret_server.0.password_field",
+				authScheme.DelineaSecretServer.PasswordField,
+			),
+		}...)
 	}
 
 	return resource.ComposeTestCheckFunc(checkFuncs...)
@@ -396,6 +447,22 @@ func setupRepositoryUserAccountConfig(resName string, userAccount useraccount.Us
 				is_dynamic_user_account = %t
 			}`, authScheme.HashicorpVault.Path,
 			authScheme.HashicorpVault.IsDynamicUserAccount)
+	case authScheme.CyberArkConjur != nil:
+		authSchemeStr = fmt.Sprintf(`
+			cyberark_conjur {
+				account = "%s"
+				variable_id = "%s"
+			}`, authScheme.CyberArkConjur.Account,
+			authScheme.CyberArkConjur.VariableID)
+	case authScheme.DelineaSecretServer != nil:
+		authSchemeStr = fmt.Sprintf(`
+			delinea_secret_server {
+				secret_id = %d
+				username_field = "%s"
+				password_field = "%s"
+			}`, authScheme.DelineaSecretServer.SecretID,
+			authScheme.DelineaSecretServer.UsernameField,
+			authScheme.DelineaSecretServer.PasswordField)
 	}
 
 	var approvalConfigStr string
@@ -0,0 +1,68 @@
+package useraccount
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	awsIAMRoleARNRegex = regexp.MustCompile(
+		`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]{1,512}$`)
+	azureKeyVaultSecretURLRegex = regexp.MustCompile(
+		`^https://[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]\.vault\.(azure\.net|azure\.cn|usgovcloudapi\.net|microsoftazure\.de)` +
+			`/secrets/[a-zA-Z0-9-]{1,127}(/[a-fA-F0-9]{32})?/?$`)
+	gcpSecretNameRegex = regexp.MustCompile(
+		`^projects/[a-z0-9-]+/secrets/[\w-]{1,255}(/versions/(latest|[1-9]\d*))?$`)
+	vaultPathRegex = regexp.MustCompile(
+		`^[\w.-]+(/[\w.-]+)*$`)
+	conjurVariableIDRegex = regexp.MustCompile(
+		`^[\w.@-]+(/[\w.@-]+)*$`)
+)
+
+func validateAWSIAMRoleARN() schema.SchemaValidateFunc {
+	return validation.StringMatch(awsIAMRoleARNRegex,
+		"must be an AWS IAM role ARN, such as `arn:aws:iam::123456789012:role/my-role`")
+}
+
+func validateAzureKeyVaultSecretURL() schema.SchemaValidateFunc {
+	return validation.StringMatch(azureKeyVaultSecretURLRegex,
+		"must be an Azure Key Vault secret URL, such as "+
+			"`https://my-vault.vault.azure.net/secrets/my-secret`, optionally followed by the secret version")
+}
+
+func validateGCPSecretName() schema.SchemaValidateFunc {
+	return validation.StringMatch(gcpSecretNameRegex,
+		"must be a GCP Secret Manager resource name, such as "+
+			"`projects/my-project/secrets/my-secret` or `projects/my-project/secrets/my-secret/versions/latest`")
+}
+
+func validateVaultPath() schema.SchemaValidateFunc {
+	return validation.StringMatch(vaultPathRegex,
+		"must be a Vault path, such as `database/creds/my-role`, without leading, "+
+			"trailing or repeated slashes")
+}
+
+func validateConjurVariableID() schema.SchemaValidateFunc {
+	return validation.StringMatch(conjurVariableIDRegex,
+		"must be a Conjur variable ID, such as `my-app/db/password`, without leading, "+
+			"trailing or repeated slashes")
+}
+
+// warnOnInvalidFormat reports the errors of the given validation function as
+// warnings. The formats of the pre-existing auth scheme attributes were not
+// validated before, so configurations that don't match them are still
+// accepted, until the validation becomes strict in the next major version of
+// the provider. Attributes of new auth schemes are validated strictly.
+func warnOnInvalidFormat(f schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		warnings, errs := f(i, k)
+		for _, err := range errs {
+			warnings = append(warnings, fmt.Sprintf("%v. Values in other formats are deprecated and "+
+				"will be rejected in the next major version of the provider.", err))
+		}
+		return warnings, nil
+	}
+}
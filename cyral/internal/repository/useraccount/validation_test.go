@@ -0,0 +1,111 @@
+package useraccount
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func TestWarnOnInvalidFormat(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		validateFunc   schema.SchemaValidateFunc
+		value          string
+		expectWarnings bool
+	}{
+		{
+			desc:         "valid role ARN",
+			validateFunc: validateAWSIAMRoleARN(),
+			value:        "arn:aws:iam::123456789012:role/role-1",
+		},
+		{
+			desc:           "legacy role ARN",
+			validateFunc:   validateAWSIAMRoleARN(),
+			value:          "role-arn-1",
+			expectWarnings: true,
+		},
+		{
+			desc:         "valid secret ARN",
+			validateFunc: utils.ValidationAWSSecretARN(),
+			value:        "arn:aws:secretsmanager:us-east-1:123456789012:secret:secret-1",
+		},
+		{
+			desc:           "legacy secret ARN",
+			validateFunc:   utils.ValidationAWSSecretARN(),
+			value:          "secret-arn-1",
+			expectWarnings: true,
+		},
+		{
+			desc:           "legacy GCP secret name",
+			validateFunc:   validateGCPSecretName(),
+			value:          "secret-name-1",
+			expectWarnings: true,
+		},
+		{
+			desc:           "legacy Vault path",
+			validateFunc:   validateVaultPath(),
+			value:          "/path-1//",
+			expectWarnings: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			warnings, errs := warnOnInvalidFormat(testCase.validateFunc)(testCase.value, "key")
+			assert.Empty(t, errs)
+			if testCase.expectWarnings {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "deprecated")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateConjurVariableID(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		value       string
+		expectError bool
+	}{
+		{
+			desc:  "valid variable ID",
+			value: "app-1/db/password",
+		},
+		{
+			desc:  "single segment",
+			value: "password",
+		},
+		{
+			desc:        "leading slash",
+			value:       "/app-1/db/password",
+			expectError: true,
+		},
+		{
+			desc:        "repeated slashes",
+			value:       "app-1//password",
+			expectError: true,
+		},
+		{
+			desc:        "empty",
+			value:       "",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			warnings, errs := validateConjurVariableID()(testCase.value, "variable_id")
+			assert.Empty(t, warnings)
+			if testCase.expectError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+var kubernetesSecretNameRegex = regexp.MustCompile(
+	`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+func validateKubernetesSecretName() schema.SchemaValidateFunc {
+	return validation.All(
+		validation.StringLenBetween(1, 253),
+		validation.StringMatch(kubernetesSecretNameRegex,
+			"must be a valid Kubernetes secret name, made of lower case alphanumeric"+
+				" characters, `-` and `.`, starting and ending with an alphanumeric character"),
+	)
+}
+
+// secretIDValidators returns the validation of the secret ID of a
+// certificate bundle secret for each secret manager type.
+func secretIDValidators() map[SecretManagerType]schema.SchemaValidateFunc {
+	return map[SecretManagerType]schema.SchemaValidateFunc{
+		SecretManagerAWS:        utils.ValidationAWSSecretARN(),
+		SecretManagerKubernetes: validateKubernetesSecretName(),
+	}
+}
+
+func certificateBundleSecretSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		EngineKey: {
+			Description: "Engine is the name of the engine used with the given secrets" +
+				" manager type, when applicable.",
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		SecretIDKey: {
+			Description: "Secret ID is the identifier or location for the secret that" +
+				" holds the certificate bundle. It must be the ARN of the secret when `type` is `aws`," +
+				" and the name of the secret when `type` is `k8s`.",
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		utils.TypeKey: {
+			Description:  "Type identifies the secret manager used to store the secret. Valid values are: `aws` and `k8s`.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(SecretManagerTypesAsString(), false),
+		},
+	}
+}
+
+// selfSignedCertificateBundleSchema returns the schema of the computed view
+// of the certificate bundle generated by the control plane for the sidecar.
+func selfSignedCertificateBundleSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Self-signed certificate bundle generated by the control plane for the sidecar, used when" +
+			" no certificate bundle is provided. Sidecars that need to trust each other's TLS certificates can" +
+			" reference it to pick up rotations of the bundle.",
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				utils.TypeKey: {
+					Description: "Secret manager used to store the certificate bundle.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				SecretIDKey: {
+					Description: "Identifier or location of the secret that holds the certificate bundle.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				EngineKey: {
+					Description: "Engine used with the secret manager, when applicable.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func flattenSelfSignedCertificateBundle(cbs CertificateBundleSecrets) []interface{} {
+	selfSigned, ok := cbs[selfSignedBundleName]
+	if !ok || selfSigned == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			utils.TypeKey: selfSigned.Type,
+			SecretIDKey:   selfSigned.SecretId,
+			EngineKey:     selfSigned.Engine,
+		},
+	}
+}
+
+// validateCertificateBundleSecretIDs warns at plan time about secret IDs
+// that do not match the format expected by their secret manager type. The
+// format was not validated before, so other values are still accepted. The
+// validation is skipped while the type or the secret ID is unknown.
+func validateCertificateBundleSecretIDs(
+	_ context.Context,
+	req schema.ValidateResourceConfigFuncRequest,
+	resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	cbsBlocks, _ := utils.RawConfigBlocks(req.RawConfig, CertificateBundleSecretsKey)
+	for _, cbs := range cbsBlocks {
+		secrets, _ := utils.RawConfigBlocks(cbs, CertificateBundleSidecarKey)
+		for _, secret := range secrets {
+			resp.Diagnostics = append(resp.Diagnostics, secretIDWarnings(secret)...)
+		}
+	}
+}
+
+func secretIDWarnings(secret cty.Value) diag.Diagnostics {
+	cbsType, ok1 := utils.RawConfigString(secret, utils.TypeKey)
+	secretID, ok2 := utils.RawConfigString(secret, SecretIDKey)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	validate, ok := secretIDValidators()[SecretManagerType(cbsType)]
+	if !ok {
+		return nil
+	}
+	_, errs := validate(secretID, SecretIDKey)
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Invalid certificate bundle secret ID",
+			Detail: fmt.Sprintf("%v in `%s.%s`. Values in other formats are deprecated and will be"+
+				" rejected in the next major version of the provider.",
+				err, CertificateBundleSecretsKey, CertificateBundleSidecarKey),
+		})
+	}
+	return diags
+}
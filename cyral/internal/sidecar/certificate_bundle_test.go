@@ -0,0 +1,78 @@
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCertificateBundleSecretIDs(t *testing.T) {
+	config := func(cbsType, secretID cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("sidecar"),
+			"certificate_bundle_secrets": cty.SetVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"sidecar": cty.SetVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"engine":    cty.NullVal(cty.String),
+							"secret_id": secretID,
+							"type":      cbsType,
+						}),
+					}),
+				}),
+			}),
+		})
+	}
+
+	testCases := []struct {
+		desc          string
+		rawConfig     cty.Value
+		expectedDiags int
+	}{
+		{
+			desc: "valid AWS secret ARN",
+			rawConfig: config(cty.StringVal("aws"),
+				cty.StringVal("arn:aws:secretsmanager:us-east-1:123456789012:secret:tls-bundle")),
+		},
+		{
+			desc:          "invalid AWS secret ARN",
+			rawConfig:     config(cty.StringVal("aws"), cty.StringVal("tls-bundle")),
+			expectedDiags: 1,
+		},
+		{
+			desc:      "valid Kubernetes secret name",
+			rawConfig: config(cty.StringVal("k8s"), cty.StringVal("tls-bundle")),
+		},
+		{
+			desc:          "invalid Kubernetes secret name",
+			rawConfig:     config(cty.StringVal("k8s"), cty.StringVal("TLS_Bundle")),
+			expectedDiags: 1,
+		},
+		{
+			desc:      "unknown secret ID skips the validation",
+			rawConfig: config(cty.StringVal("aws"), cty.UnknownVal(cty.String)),
+		},
+		{
+			desc: "no certificate bundle secrets",
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"name":                       cty.StringVal("sidecar"),
+				"certificate_bundle_secrets": cty.NullVal(cty.Set(cty.EmptyObject)),
+			}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateCertificateBundleSecretIDs(
+				context.Background(),
+				schema.ValidateResourceConfigFuncRequest{RawConfig: testCase.rawConfig},
+				resp,
+			)
+			assert.Len(t, resp.Diagnostics, testCase.expectedDiags)
+		})
+	}
+}
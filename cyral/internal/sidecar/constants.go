@@ -1,7 +1,73 @@
 package sidecar
 
+import (
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
 const (
 	resourceName             = "cyral_sidecar"
 	dataSourceIdName         = "cyral_sidecar_id"
 	dataSourceBoundPortsName = "cyral_sidecar_bound_ports"
+	dataSourceSidecarsName   = "cyral_sidecars"
+
+	// Data source
+	SidecarListKey                = "sidecar_list"
+	SidecarIDsKey                 = "ids"
+	LabelsKey                     = "labels"
+	LabelsMatchKey                = "labels_match"
+	DeploymentMethodKey           = "deployment_method"
+	BypassModeKey                 = "bypass_mode"
+	UserEndpointKey               = "user_endpoint"
+	ActivityLogIntegrationIDKey   = "activity_log_integration_id"
+	DiagnosticLogIntegrationIDKey = "diagnostic_log_integration_id"
+	VaultIntegrationIDKey         = "vault_integration_id"
+
+	// Certificate bundle secrets
+	CertificateBundleSecretsKey    = "certificate_bundle_secrets"
+	CertificateBundleSidecarKey    = "sidecar"
+	SelfSignedCertificateBundleKey = "self_signed_certificate_bundle"
+	EngineKey                      = "engine"
+	SecretIDKey                    = "secret_id"
+
+	// Services
+	ServicesKey = "services"
+	SettingsKey = "settings"
+
+	// selfSignedBundleName is the key under which the control plane stores
+	// the certificate bundle it generates for the sidecar. It is not managed
+	// by the user and is exposed as SelfSignedCertificateBundleKey.
+	selfSignedBundleName = "sidecar-generated-selfsigned"
+
+	// dispatcherService is the sidecar service whose bypassSetting is
+	// managed through BypassModeKey.
+	dispatcherService = "dispatcher"
+	bypassSetting     = "bypass"
 )
+
+func deploymentMethods() []string {
+	return []string{
+		"docker", "cft-ec2", "terraform", "helm3",
+		"automated", "custom", "terraformGKE", "singleContainer",
+		"linux",
+	}
+}
+
+type SecretManagerType string
+
+const (
+	SecretManagerAWS        = SecretManagerType("aws")
+	SecretManagerKubernetes = SecretManagerType("k8s")
+)
+
+func SecretManagerTypes() []SecretManagerType {
+	return []SecretManagerType{
+		SecretManagerAWS,
+		SecretManagerKubernetes,
+	}
+}
+
+func SecretManagerTypesAsString() []string {
+	return utils.ToSliceOfString[SecretManagerType](SecretManagerTypes(), func(t SecretManagerType) string {
+		return string(t)
+	})
+}
@@ -2,4 +2,17 @@ package credentials
 
 const (
 	resourceName = "cyral_sidecar_credentials"
+
+	// Schema keys
+	ClientIDKey             = "client_id"
+	ClientSecretKey         = "client_secret"
+	RotateAfterKey          = "rotate_after"
+	KeepersKey              = "keepers"
+	OverlapKey              = "overlap"
+	RotatedAtKey            = "rotated_at"
+	PreviousClientIDKey     = "previous_client_id"
+	PreviousClientSecretKey = "previous_client_secret"
+	PreviousExpiresAtKey    = "previous_expires_at"
+
+	defaultOverlap = "0s"
 )
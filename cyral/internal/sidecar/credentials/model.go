@@ -1,9 +1,16 @@
 package credentials
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 type CreateSidecarCredentialsRequest struct {
@@ -11,7 +18,7 @@ type CreateSidecarCredentialsRequest struct {
 }
 
 func (r *CreateSidecarCredentialsRequest) ReadFromSchema(d *schema.ResourceData) error {
-	r.SidecarID = d.Get("sidecar_id").(string)
+	r.SidecarID = d.Get(utils.SidecarIDKey).(string)
 	return nil
 }
 
@@ -22,17 +29,56 @@ type SidecarCredentialsData struct {
 }
 
 func (r *SidecarCredentialsData) WriteToSchema(d *schema.ResourceData) error {
-	if err := d.Set("client_id", r.ClientID); err != nil {
-		return fmt.Errorf("error setting 'client_id' field: %w", err)
+	if err := d.Set(ClientIDKey, r.ClientID); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, ClientIDKey, err)
 	}
 	if r.ClientSecret != "" {
-		if err := d.Set("client_secret", r.ClientSecret); err != nil {
-			return fmt.Errorf("error setting 'client_secret' field: %w", err)
+		if err := d.Set(ClientSecretKey, r.ClientSecret); err != nil {
+			return fmt.Errorf(utils.ErrorSettingFieldFmt, ClientSecretKey, err)
 		}
 	}
-	if err := d.Set("sidecar_id", r.SidecarID); err != nil {
-		return fmt.Errorf("error setting 'sidecar_id' field: %w", err)
+	if err := d.Set(utils.SidecarIDKey, r.SidecarID); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, utils.SidecarIDKey, err)
 	}
 	d.SetId(r.ClientID)
 	return nil
 }
+
+func sidecarAccountsURL(c *client.Client) string {
+	return fmt.Sprintf("https://%s/v1/users/sidecarAccounts", c.ControlPlane)
+}
+
+// createSidecarCredentials creates new credentials for the given sidecar. The
+// client secret is only available in the response of this call.
+func createSidecarCredentials(
+	ctx context.Context,
+	c *client.Client,
+	sidecarID string,
+) (*SidecarCredentialsData, error) {
+	tflog.Debug(ctx, "Init createSidecarCredentials")
+	request := &CreateSidecarCredentialsRequest{SidecarID: sidecarID}
+	body, err := c.DoRequest(ctx, sidecarAccountsURL(c), http.MethodPost, request)
+	if err != nil {
+		return nil, err
+	}
+	credentials := &SidecarCredentialsData{}
+	if err := json.Unmarshal(body, credentials); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, "End createSidecarCredentials")
+	return credentials, nil
+}
+
+// deleteSidecarCredentials revokes the credentials with the given client ID.
+// Credentials that no longer exist are ignored.
+func deleteSidecarCredentials(ctx context.Context, c *client.Client, clientID string) error {
+	tflog.Debug(ctx, "Init deleteSidecarCredentials")
+	url := fmt.Sprintf("%s/%s", sidecarAccountsURL(c), clientID)
+	if _, err := c.DoRequest(ctx, url, http.MethodDelete, nil); err != nil {
+		if !client.IsNotFound(err) {
+			return err
+		}
+	}
+	tflog.Debug(ctx, "End deleteSidecarCredentials")
+	return nil
+}
@@ -1,12 +1,18 @@
 package credentials
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/resourcetype"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 var resourceContextHandler = core.HTTPContextHandler{
@@ -16,43 +22,199 @@ var resourceContextHandler = core.HTTPContextHandler{
 	SchemaWriterFactoryGetMethod:  func(_ *schema.ResourceData) core.SchemaWriter { return &SidecarCredentialsData{} },
 	SchemaWriterFactoryPostMethod: func(_ *schema.ResourceData) core.SchemaWriter { return &SidecarCredentialsData{} },
 	BaseURLFactory: func(d *schema.ResourceData, c *client.Client) string {
-		return fmt.Sprintf("https://%s/v1/users/sidecarAccounts", c.ControlPlane)
+		return sidecarAccountsURL(c)
 	},
 }
 
 func resourceSchema() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Create new [credentials for Cyral sidecar](https://cyral.com/docs/sidecars/manage/#rotate-the-client-secret-for-a-sidecar).",
-		CreateContext: resourceContextHandler.CreateContext(),
-		ReadContext:   resourceContextHandler.ReadContext(),
-		DeleteContext: resourceContextHandler.DeleteContext(),
+		Description: "Create new [credentials for Cyral sidecar](https://cyral.com/docs/sidecars/manage/#rotate-the-client-secret-for-a-sidecar)." +
+			"\n\nThe credentials are rotated in place when `" + KeepersKey + "` change or, if `" + RotateAfterKey +
+			"` is set, by the first apply after they get older than `" + RotateAfterKey + "`. New credentials are " +
+			"created before the current ones are revoked. If `" + OverlapKey + "` is set, the replaced credentials " +
+			"are exposed as `" + PreviousClientIDKey + "` and `" + PreviousClientSecretKey + "` and only revoked " +
+			"by the first apply after the overlap window ends, so the sidecar instances can pick up the new secret " +
+			"without downtime.",
+		CreateContext: resourceSidecarCredentialsCreate,
+		ReadContext:   resourceSidecarCredentialsRead,
+		UpdateContext: resourceSidecarCredentialsUpdate,
+		DeleteContext: resourceSidecarCredentialsDelete,
+		CustomizeDiff: resourceSidecarCredentialsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
-			"id": {
+			utils.IDKey: {
 				Description: "Same as `client_id`.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"sidecar_id": {
+			utils.SidecarIDKey: {
 				Description: "ID of the sidecar to create new credentials.",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
 			},
-			"client_id": {
+			ClientIDKey: {
 				Description: "Sidecar client ID.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"client_secret": {
+			ClientSecretKey: {
 				Description: "Sidecar client secret.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
 			},
+			RotateAfterKey: {
+				Description: "Maximum age of the credentials, as a duration string such as `720h`. If set, the " +
+					"credentials are rotated by the first apply after they get older than this value.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: utils.ValidationParsableDuration(false),
+			},
+			KeepersKey: {
+				Description: "Arbitrary map of values that, when changed, rotates the credentials.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			OverlapKey: {
+				Description: fmt.Sprintf("Time during which the replaced credentials remain valid after a "+
+					"rotation, as a duration string such as `1h`. If `0s`, the replaced credentials are revoked "+
+					"right after the new ones are created. Defaults to `%s`.", defaultOverlap),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultOverlap,
+				ValidateFunc: utils.ValidationParsableDuration(true),
+			},
+			RotatedAtKey: {
+				Description: "Time when the current credentials were created by this resource, in RFC3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			PreviousClientIDKey: {
+				Description: "Client ID of the credentials replaced by the last rotation, while they are in " +
+					"their overlap window.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PreviousClientSecretKey: {
+				Description: "Client secret of the credentials replaced by the last rotation, while they are " +
+					"in their overlap window.",
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			PreviousExpiresAtKey: {
+				Description: "Time when the overlap window of the previous credentials ends, in RFC3339 format. " +
+					"The previous credentials are revoked by the first apply after this time.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: func(
+				ctx context.Context,
+				d *schema.ResourceData,
+				m interface{},
+			) ([]*schema.ResourceData, error) {
+				if err := d.Set(OverlapKey, defaultOverlap); err != nil {
+					return nil, fmt.Errorf(utils.ErrorSettingFieldFmt, OverlapKey, err)
+				}
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 	}
 }
+
+func resourceSidecarCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceSidecarCredentialsCreate")
+	if diags := resourceContextHandler.CreateContext()(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if err := d.Set(RotatedAtKey, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return utils.CreateError("Unable to create sidecar credentials",
+			fmt.Errorf(utils.ErrorSettingFieldFmt, RotatedAtKey, err).Error())
+	}
+	tflog.Debug(ctx, "End resourceSidecarCredentialsCreate")
+	return nil
+}
+
+// resourceSidecarCredentialsRead reads the credentials. The creation time of
+// credentials imported or created by previous versions of the provider is
+// unknown, so their age is counted from the first read.
+func resourceSidecarCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := resourceContextHandler.ReadContext()(ctx, d, m); diags.HasError() || d.Id() == "" {
+		return diags
+	}
+	if d.Get(RotatedAtKey).(string) == "" {
+		if err := d.Set(RotatedAtKey, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return utils.CreateError("Unable to read sidecar credentials",
+				fmt.Errorf(utils.ErrorSettingFieldFmt, RotatedAtKey, err).Error())
+		}
+	}
+	return nil
+}
+
+// resourceSidecarCredentialsUpdate applies what was planned by
+// resourceSidecarCredentialsCustomizeDiff: a rotation, planned as a new client
+// ID, or the removal of the previous credentials.
+func resourceSidecarCredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceSidecarCredentialsUpdate")
+	c := m.(*client.Client)
+	oldPreviousClientID, newPreviousClientID := d.GetChange(PreviousClientIDKey)
+	if d.HasChange(ClientIDKey) {
+		if err := rotateCredentials(ctx, c, d, time.Now()); err != nil {
+			return utils.CreateError("Unable to rotate sidecar credentials", err.Error())
+		}
+	} else if oldPreviousClientID.(string) != "" && newPreviousClientID.(string) == "" {
+		if err := revokePreviousCredentials(ctx, c, d, oldPreviousClientID.(string)); err != nil {
+			return utils.CreateError("Unable to revoke previous sidecar credentials", err.Error())
+		}
+	}
+	tflog.Debug(ctx, "End resourceSidecarCredentialsUpdate")
+	return resourceSidecarCredentialsRead(ctx, d, m)
+}
+
+func resourceSidecarCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceSidecarCredentialsDelete")
+	c := m.(*client.Client)
+	if previousClientID := d.Get(PreviousClientIDKey).(string); previousClientID != "" {
+		if err := deleteSidecarCredentials(ctx, c, previousClientID); err != nil {
+			return utils.CreateError("Unable to revoke previous sidecar credentials", err.Error())
+		}
+	}
+	tflog.Debug(ctx, "End resourceSidecarCredentialsDelete")
+	return resourceContextHandler.DeleteContext()(ctx, d, m)
+}
+
+// resourceSidecarCredentialsCustomizeDiff plans the rotation of the
+// credentials when it is due, and the removal of the previous credentials
+// once their overlap window ends.
+func resourceSidecarCredentialsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	t := time.Now()
+	rotatedAt, _ := d.GetChange(RotatedAtKey)
+	if rotationDue(d.HasChange(KeepersKey), d.Get(RotateAfterKey).(string), rotatedAt.(string), t) {
+		keys := append([]string{utils.IDKey, ClientIDKey, ClientSecretKey, RotatedAtKey}, previousCredentialsKeys()...)
+		for _, key := range keys {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	previousClientID, _ := d.GetChange(PreviousClientIDKey)
+	expiresAt, _ := d.GetChange(PreviousExpiresAtKey)
+	if previousExpired(previousClientID.(string), expiresAt.(string), t) {
+		for _, key := range previousCredentialsKeys() {
+			if err := d.SetNew(key, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package credentials_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
@@ -27,7 +28,7 @@ func TestAccSidecarCredentialsResource(t *testing.T) {
 				{
 					ImportState:             true,
 					ImportStateVerify:       true,
-					ImportStateVerifyIgnore: []string{"client_secret"},
+					ImportStateVerifyIgnore: []string{"client_secret", "rotated_at"},
 					ResourceName:            "cyral_sidecar_credentials.test_sidecar_credentials",
 				},
 			},
@@ -35,6 +36,70 @@ func TestAccSidecarCredentialsResource(t *testing.T) {
 	)
 }
 
+func TestAccSidecarCredentialsResource_Rotation(t *testing.T) {
+	resourceFullName := "cyral_sidecar_credentials.test_sidecar_credentials"
+	var firstClientID string
+
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      createSidecarCredentialsRotationConfig("rotate_after = \"0s\"", ""),
+					ExpectError: regexp.MustCompile(`"rotate_after" must be a positive duration`),
+				},
+				{
+					Config:      createSidecarCredentialsRotationConfig("overlap = \"-1h\"", ""),
+					ExpectError: regexp.MustCompile(`"overlap" must be a non-negative duration`),
+				},
+				{
+					Config: createSidecarCredentialsRotationConfig("overlap = \"1h\"", "v1"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(resourceFullName, "rotated_at"),
+						resource.TestCheckResourceAttr(resourceFullName, "previous_client_id", ""),
+						resource.TestCheckResourceAttrWith(resourceFullName, "client_id", func(value string) error {
+							firstClientID = value
+							return nil
+						}),
+					),
+				},
+				{
+					// Changing the keepers rotates the credentials, and the
+					// replaced ones are kept during the overlap window.
+					Config: createSidecarCredentialsRotationConfig("overlap = \"1h\"", "v2"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(resourceFullName, "client_secret"),
+						resource.TestCheckResourceAttrSet(resourceFullName, "previous_client_secret"),
+						resource.TestCheckResourceAttrSet(resourceFullName, "previous_expires_at"),
+						resource.TestCheckResourceAttrWith(resourceFullName, "client_id", func(value string) error {
+							if value == firstClientID {
+								return fmt.Errorf("expected credentials to be rotated, got same client ID %q", value)
+							}
+							return nil
+						}),
+						resource.TestCheckResourceAttrWith(resourceFullName, "previous_client_id", func(value string) error {
+							if value != firstClientID {
+								return fmt.Errorf("expected previous client ID %q, got %q", firstClientID, value)
+							}
+							return nil
+						}),
+					),
+				},
+				{
+					// Without overlap, the replaced credentials are revoked
+					// right away, along with the previous ones.
+					Config: createSidecarCredentialsRotationConfig("", "v3"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceFullName, "previous_client_id", ""),
+						resource.TestCheckResourceAttr(resourceFullName, "previous_client_secret", ""),
+						resource.TestCheckResourceAttr(resourceFullName, "previous_expires_at", ""),
+					),
+				},
+			},
+		},
+	)
+}
+
 func setupSidecarCredentialsTest() (string, resource.TestCheckFunc) {
 	configuration := createSidecarCredentialsConfig()
 
@@ -75,3 +140,22 @@ func createSidecarCredentialsConfig() string {
 	)
 	return config
 }
+
+func createSidecarCredentialsRotationConfig(argument, keeper string) string {
+	keepers := ""
+	if keeper != "" {
+		keepers = fmt.Sprintf(`keepers = { version = %q }`, keeper)
+	}
+	return utils.FormatBasicSidecarIntoConfig(
+		utils.BasicSidecarResName,
+		utils.AccTestName(sidecarCredentialsResourceName, "sidecar-rotation"),
+		"docker", "",
+	) + fmt.Sprintf(
+		`
+	resource "cyral_sidecar_credentials" "test_sidecar_credentials" {
+		sidecar_id = %s
+		%s
+		%s
+	}`, utils.BasicSidecarID, argument, keepers,
+	)
+}
@@ -0,0 +1,114 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func previousCredentialsKeys() []string {
+	return []string{PreviousClientIDKey, PreviousClientSecretKey, PreviousExpiresAtKey}
+}
+
+// rotationDue returns true if the keepers changed or if the current
+// credentials, created at rotatedAt, are older than rotateAfter at time t.
+func rotationDue(keepersChanged bool, rotateAfter, rotatedAt string, t time.Time) bool {
+	if keepersChanged {
+		return true
+	}
+	maxAge, err := time.ParseDuration(rotateAfter)
+	if err != nil {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	return !t.Before(createdAt.Add(maxAge))
+}
+
+// previousExpired returns true if there are previous credentials whose
+// overlap window, ending at expiresAt, has ended at time t.
+func previousExpired(previousClientID, expiresAt string, t time.Time) bool {
+	if previousClientID == "" {
+		return false
+	}
+	end, err := time.Parse(time.RFC3339, expiresAt)
+	return err != nil || !t.Before(end)
+}
+
+func setPreviousCredentials(d *schema.ResourceData, clientID, clientSecret, expiresAt string) error {
+	fields := map[string]string{
+		PreviousClientIDKey:     clientID,
+		PreviousClientSecretKey: clientSecret,
+		PreviousExpiresAtKey:    expiresAt,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf(utils.ErrorSettingFieldFmt, key, err)
+		}
+	}
+	return nil
+}
+
+// rotateCredentials creates new credentials for the sidecar before revoking
+// the current ones. If `overlap` is set, the current credentials are kept as
+// the previous credentials until the overlap window ends, so the sidecar
+// instances can pick up the new secret without downtime.
+func rotateCredentials(ctx context.Context, c *client.Client, d *schema.ResourceData, t time.Time) error {
+	oldClientID, _ := d.GetChange(ClientIDKey)
+	oldClientSecret, _ := d.GetChange(ClientSecretKey)
+	oldPreviousClientID, _ := d.GetChange(PreviousClientIDKey)
+	overlap, err := time.ParseDuration(d.Get(OverlapKey).(string))
+	if err != nil {
+		return fmt.Errorf("invalid %q: %w", OverlapKey, err)
+	}
+
+	// Only one set of previous credentials is kept, so the ones still in
+	// their overlap window are revoked before creating new credentials.
+	if previousClientID := oldPreviousClientID.(string); previousClientID != "" {
+		if err := deleteSidecarCredentials(ctx, c, previousClientID); err != nil {
+			return fmt.Errorf("unable to revoke previous client ID %q: %w", previousClientID, err)
+		}
+	}
+
+	credentials, err := createSidecarCredentials(ctx, c, d.Get(utils.SidecarIDKey).(string))
+	if err != nil {
+		return fmt.Errorf("unable to create new credentials: %w", err)
+	}
+	if err := credentials.WriteToSchema(d); err != nil {
+		return err
+	}
+	if err := d.Set(RotatedAtKey, t.UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf(utils.ErrorSettingFieldFmt, RotatedAtKey, err)
+	}
+	// The current credentials are kept as the previous ones, so they are
+	// revoked by the next apply if revoking them below fails.
+	if err := setPreviousCredentials(d, oldClientID.(string), oldClientSecret.(string),
+		t.Add(overlap).UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if overlap > 0 {
+		return nil
+	}
+	return revokePreviousCredentials(ctx, c, d, oldClientID.(string))
+}
+
+// revokePreviousCredentials revokes the previous credentials and removes
+// them from the state.
+func revokePreviousCredentials(
+	ctx context.Context,
+	c *client.Client,
+	d *schema.ResourceData,
+	previousClientID string,
+) error {
+	if err := deleteSidecarCredentials(ctx, c, previousClientID); err != nil {
+		return fmt.Errorf("unable to revoke previous client ID %q: %w", previousClientID, err)
+	}
+	return setPreviousCredentials(d, "", "", "")
+}
@@ -0,0 +1,106 @@
+package credentials
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotationDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc           string
+		keepersChanged bool
+		rotateAfter    string
+		rotatedAt      string
+		expected       bool
+	}{
+		{
+			desc:           "keepers changed",
+			keepersChanged: true,
+			expected:       true,
+		},
+		{
+			desc:      "without rotate_after",
+			rotatedAt: "2020-01-01T00:00:00Z",
+		},
+		{
+			desc:        "younger than rotate_after",
+			rotateAfter: "24h",
+			rotatedAt:   "2024-05-31T12:00:01Z",
+		},
+		{
+			desc:        "exactly rotate_after old",
+			rotateAfter: "24h",
+			rotatedAt:   "2024-05-31T12:00:00Z",
+			expected:    true,
+		},
+		{
+			desc:        "older than rotate_after",
+			rotateAfter: "1h",
+			rotatedAt:   "2024-05-31T12:00:00Z",
+			expected:    true,
+		},
+		{
+			desc:        "unknown creation time",
+			rotateAfter: "1h",
+		},
+		{
+			desc:        "invalid creation time",
+			rotateAfter: "1h",
+			rotatedAt:   "yesterday",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected,
+				rotationDue(testCase.keepersChanged, testCase.rotateAfter, testCase.rotatedAt, now))
+		})
+	}
+}
+
+func TestPreviousExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc             string
+		previousClientID string
+		expiresAt        string
+		expected         bool
+	}{
+		{
+			desc:      "without previous credentials",
+			expiresAt: "2024-05-01T00:00:00Z",
+		},
+		{
+			desc:             "in the overlap window",
+			previousClientID: "client-id",
+			expiresAt:        "2024-06-01T12:00:01Z",
+		},
+		{
+			desc:             "at the end of the overlap window",
+			previousClientID: "client-id",
+			expiresAt:        "2024-06-01T12:00:00Z",
+			expected:         true,
+		},
+		{
+			desc:             "after the overlap window",
+			previousClientID: "client-id",
+			expiresAt:        "2024-05-01T00:00:00Z",
+			expected:         true,
+		},
+		{
+			desc:             "unknown end of the overlap window",
+			previousClientID: "client-id",
+			expected:         true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected,
+				previousExpired(testCase.previousClientID, testCase.expiresAt, now))
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func (r *SidecarData) ToMap(sidecarID string) map[string]any {
+	sidecarMap := map[string]any{
+		utils.IDKey:     sidecarID,
+		utils.NameKey:   r.Name,
+		LabelsKey:       r.Labels,
+		UserEndpointKey: r.UserEndpoint,
+		BypassModeKey:   r.BypassMode(),
+	}
+	if r.SidecarProperties != nil {
+		sidecarMap[DeploymentMethodKey] = r.SidecarProperties.DeploymentMethod
+		sidecarMap[ActivityLogIntegrationIDKey] = r.SidecarProperties.LogIntegrationID
+		sidecarMap[DiagnosticLogIntegrationIDKey] = r.SidecarProperties.DiagnosticLogIntegrationID
+		sidecarMap[VaultIntegrationIDKey] = r.SidecarProperties.VaultIntegrationID
+	}
+	sidecarMap[SelfSignedCertificateBundleKey] = flattenSelfSignedCertificateBundle(r.CertificateBundleSecrets)
+	return sidecarMap
+}
+
+// sidecarFilter holds the filters of the sidecars data source, which are
+// applied locally as the API does not support them.
+type sidecarFilter struct {
+	nameRegex        *regexp.Regexp
+	labels           []string
+	labelsMatch      string
+	deploymentMethod string
+}
+
+func (f *sidecarFilter) matches(sidecar *SidecarData) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(sidecar.Name) {
+		return false
+	}
+	if !utils.MatchLabels(sidecar.Labels, f.labels, f.labelsMatch) {
+		return false
+	}
+	if f.deploymentMethod != "" {
+		if sidecar.SidecarProperties == nil || sidecar.SidecarProperties.DeploymentMethod != f.deploymentMethod {
+			return false
+		}
+	}
+	return true
+}
+
+func dataSourceSidecarsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceSidecarsRead")
+	c := m.(*client.Client)
+
+	filter := &sidecarFilter{
+		labels:           utils.GetStrListFromSchemaField(d, LabelsKey),
+		labelsMatch:      d.Get(LabelsMatchKey).(string),
+		deploymentMethod: d.Get(DeploymentMethodKey).(string),
+	}
+	if nameFilter := d.Get(utils.NameKey).(string); nameFilter != "" {
+		nameRegex, err := regexp.Compile(nameFilter)
+		if err != nil {
+			return utils.CreateError("Invalid name filter", err.Error())
+		}
+		filter.nameRegex = nameRegex
+	}
+
+	sidecarsInfo, err := ListSidecars(c)
+	if err != nil {
+		return utils.CreateError("Unable to retrieve the list of existent sidecars.", err.Error())
+	}
+	var filtered []IdentifiedSidecarInfo
+	for _, sidecarInfo := range sidecarsInfo {
+		if filter.matches(&sidecarInfo.Sidecar) {
+			filtered = append(filtered, sidecarInfo)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Sidecar.Name < filtered[j].Sidecar.Name
+	})
+
+	sidecarList := make([]any, 0, len(filtered))
+	sidecarIDs := make([]string, 0, len(filtered))
+	for _, sidecarInfo := range filtered {
+		sidecarList = append(sidecarList, sidecarInfo.Sidecar.ToMap(sidecarInfo.ID))
+		sidecarIDs = append(sidecarIDs, sidecarInfo.ID)
+	}
+	if err := d.Set(SidecarListKey, sidecarList); err != nil {
+		return utils.CreateError("Unable to read sidecars",
+			fmt.Errorf(utils.ErrorSettingFieldFmt, SidecarListKey, err).Error())
+	}
+	if err := d.Set(SidecarIDsKey, sidecarIDs); err != nil {
+		return utils.CreateError("Unable to read sidecars",
+			fmt.Errorf(utils.ErrorSettingFieldFmt, SidecarIDsKey, err).Error())
+	}
+	d.SetId(uuid.New().String())
+
+	tflog.Debug(ctx, "End dataSourceSidecarsRead")
+	return nil
+}
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves a list of sidecars. See [`" + SidecarListKey + "`](#nestedatt--" + SidecarListKey +
+			"). To retrieve the ID of a single sidecar by its name, see " +
+			"[`cyral_sidecar_id`](./sidecar_id.md).",
+		ReadContext: dataSourceSidecarsRead,
+		Schema: map[string]*schema.Schema{
+			utils.NameKey: {
+				Description:  "Filter the results by a regular expression (regex) that matches names of existing sidecars.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			LabelsKey: {
+				Description: "Filter the results by sidecar labels. See [`" + LabelsMatchKey + "`](#" +
+					LabelsMatchKey + ") for how multiple labels are combined.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			LabelsMatchKey: {
+				Description: "Defines how the `" + LabelsKey + "` filter is applied. If `" +
+					utils.LabelsMatchAll + "`, only sidecars that have all the given labels are returned. If `" +
+					utils.LabelsMatchAny + "`, sidecars that have at least one of the given labels are " +
+					"returned. Defaults to `" + utils.LabelsMatchAll + "`. List of supported values:" +
+					utils.SupportedValuesAsMarkdown(utils.LabelsMatchModes()),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      utils.LabelsMatchAll,
+				ValidateFunc: validation.StringInSlice(utils.LabelsMatchModes(), false),
+			},
+			DeploymentMethodKey: {
+				Description: "Filter the results by deployment method. List of supported values:" +
+					utils.SupportedValuesAsMarkdown(deploymentMethods()),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(deploymentMethods(), false),
+			},
+			utils.IDKey: {
+				Description: "Data source identifier.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			SidecarIDsKey: {
+				Description: "IDs of the sidecars satisfying the filter criteria, in the same order as `" +
+					SidecarListKey + "`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			SidecarListKey: {
+				Description: "List of existing sidecars satisfying the filter criteria, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						utils.IDKey: {
+							Description: "ID of the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						utils.NameKey: {
+							Description: "Name of the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						LabelsKey: {
+							Description: "Labels of the sidecar.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						DeploymentMethodKey: {
+							Description: "Deployment method of the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						BypassModeKey: {
+							Description: "Bypass mode of the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ActivityLogIntegrationIDKey: {
+							Description: "ID of the log integration used for Cyral activity logs.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						DiagnosticLogIntegrationIDKey: {
+							Description: "ID of the log integration used for sidecar diagnostic logs.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						VaultIntegrationIDKey: {
+							Description: "ID of the HashiCorp Vault integration associated to the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						UserEndpointKey: {
+							Description: "User-defined endpoint of the sidecar.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						SelfSignedCertificateBundleKey: selfSignedCertificateBundleSchema(),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package sidecar_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+const (
+	sidecarsDataSourceName = "data-sidecars"
+)
+
+func sidecarsDataSourceSidecarsConfig() string {
+	return fmt.Sprintf(`
+	resource "cyral_sidecar" "docker_sidecar" {
+		name              = "%s"
+		deployment_method = "docker"
+		labels            = ["us-east-1", "prod"]
+	}
+
+	resource "cyral_sidecar" "helm_sidecar" {
+		name              = "%s"
+		deployment_method = "helm3"
+		labels            = ["us-east-2", "prod"]
+	}`,
+		utils.AccTestName(sidecarsDataSourceName, "docker"),
+		utils.AccTestName(sidecarsDataSourceName, "helm"),
+	)
+}
+
+func sidecarsDataSourceConfig(filters string) string {
+	return sidecarsDataSourceSidecarsConfig() + fmt.Sprintf(`
+	data "cyral_sidecars" "test" {
+		depends_on = [cyral_sidecar.docker_sidecar, cyral_sidecar.helm_sidecar]
+		name       = %q
+		%s
+	}`, "^"+regexp.QuoteMeta(utils.AccTestName(sidecarsDataSourceName, "")), filters)
+}
+
+func TestAccSidecarsDataSource(t *testing.T) {
+	dataSourceFullName := "data.cyral_sidecars.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      sidecarsDataSourceConfig(`deployment_method = "kubernetes"`),
+				ExpectError: regexp.MustCompile(`expected deployment_method to be one of`),
+			},
+			{
+				Config: sidecarsDataSourceConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_list.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "ids.#", "2"),
+					// Sidecars are sorted by name.
+					resource.TestCheckResourceAttrPair(
+						dataSourceFullName, "sidecar_list.0.id",
+						"cyral_sidecar.docker_sidecar", "id",
+					),
+					resource.TestCheckResourceAttr(dataSourceFullName,
+						"sidecar_list.0.deployment_method", "docker"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_list.0.labels.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceFullName, "ids.1",
+						"cyral_sidecar.helm_sidecar", "id",
+					),
+				),
+			},
+			{
+				Config: sidecarsDataSourceConfig(`deployment_method = "helm3"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_list.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceFullName, "sidecar_list.0.id",
+						"cyral_sidecar.helm_sidecar", "id",
+					),
+				),
+			},
+			{
+				Config: sidecarsDataSourceConfig(`labels = ["prod", "us-east-1"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_list.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceFullName, "sidecar_list.0.id",
+						"cyral_sidecar.docker_sidecar", "id",
+					),
+				),
+			},
+			{
+				Config: sidecarsDataSourceConfig(`
+					labels       = ["us-east-1", "us-east-2"]
+					labels_match = "any"
+				`),
+				Check: resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_list.#", "2"),
+			},
+		},
+	})
+}
@@ -1,5 +1,25 @@
 package health
 
+import "time"
+
 const (
 	dataSourceName = "cyral_sidecar_health"
 )
+
+const (
+	// Schema keys
+	WaitForHealthyKey = "wait_for_healthy"
+	MinInstancesKey   = "min_instances"
+
+	DefaultWaitTimeout      = 10 * time.Minute
+	defaultWaitMinInstances = 1
+	waitPollInterval        = 10 * time.Second
+)
+
+// Sidecar and instance health statuses.
+const (
+	StatusHealthy   = "HEALTHY"
+	StatusDegraded  = "DEGRADED"
+	StatusUnhealthy = "UNHEALTHY"
+	StatusUnknown   = "UNKNOWN"
+)
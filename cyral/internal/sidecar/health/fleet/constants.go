@@ -0,0 +1,27 @@
+package fleet
+
+const (
+	dataSourceName = "cyral_sidecar_fleet_health"
+)
+
+const (
+	// Schema keys
+	LabelsKey                = "labels"
+	LabelsMatchKey           = "labels_match"
+	OnlyFailingKey           = "only_failing"
+	SidecarsKey              = "sidecars"
+	ComponentsKey            = "components"
+	SidecarNameKey           = "sidecar_name"
+	InstanceIDKey            = "instance_id"
+	InstanceCountKey         = "instance_count"
+	HealthyInstanceCountKey  = "healthy_instance_count"
+	ServiceKey               = "service"
+	ComponentKey             = "component"
+	ErrorKey                 = "error"
+	SidecarCountKey          = "sidecar_count"
+	TotalInstanceCountKey    = "total_instance_count"
+	FailingComponentCountKey = "failing_component_count"
+	SidecarStatusCountsKey   = "sidecar_status_counts"
+	InstanceStatusCountsKey  = "instance_status_counts"
+	ComponentStatusCountsKey = "component_status_counts"
+)
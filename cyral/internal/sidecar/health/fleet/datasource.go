@@ -0,0 +1,247 @@
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func statusCountsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description + " Keys are statuses, such as `" + health.StatusHealthy + "` or `" +
+			health.StatusUnhealthy + "`, and statuses without occurrences are omitted.",
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+	}
+}
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the health of all sidecars, or of the ones with the given labels, flattened into " +
+			"one row per component of each service of each sidecar instance, along with aggregated counts. " +
+			"Useful to drive `check` blocks and alerts. For the aggregated health of a single sidecar, see " +
+			"[`cyral_sidecar_health`](./sidecar_health.md).",
+		ReadContext: dataSourceSidecarFleetHealthRead,
+		Schema: map[string]*schema.Schema{
+			LabelsKey: {
+				Description: "Filter the results by sidecar labels. See [`" + LabelsMatchKey + "`](#" +
+					LabelsMatchKey + ") for how multiple labels are combined.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			LabelsMatchKey: {
+				Description: "Defines how the `" + LabelsKey + "` filter is applied. If `" +
+					utils.LabelsMatchAll + "`, only sidecars that have all the given labels are considered. If `" +
+					utils.LabelsMatchAny + "`, sidecars that have at least one of the given labels are " +
+					"considered. Defaults to `" + utils.LabelsMatchAll + "`. List of supported values:" +
+					utils.SupportedValuesAsMarkdown(utils.LabelsMatchModes()),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      utils.LabelsMatchAll,
+				ValidateFunc: validation.StringInSlice(utils.LabelsMatchModes(), false),
+			},
+			OnlyFailingKey: {
+				Description: "If `true`, `" + ComponentsKey + "` only contains the components whose status is not `" +
+					health.StatusHealthy + "`. The aggregated counts always consider all components. Defaults to `false`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			utils.IDKey: {
+				Description: "Data source identifier.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			SidecarsKey: {
+				Description: "Sidecars considered, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						utils.SidecarIDKey: {
+							Description: "Sidecar identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						utils.NameKey: {
+							Description: "Sidecar name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						LabelsKey: {
+							Description: "Sidecar labels.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						utils.StatusKey: {
+							Description: "Aggregated health status of the sidecar, considering all its instances.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						InstanceCountKey: {
+							Description: "Number of instances of the sidecar.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						HealthyInstanceCountKey: {
+							Description: "Number of instances of the sidecar whose status is `" +
+								health.StatusHealthy + "`.",
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			ComponentsKey: {
+				Description: "Status of each component of each service of each sidecar instance, sorted by " +
+					"sidecar name, instance, service and component. Services without components are represented " +
+					"by a single row with an empty `" + ComponentKey + "`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						utils.SidecarIDKey: {
+							Description: "Sidecar identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						SidecarNameKey: {
+							Description: "Sidecar name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						InstanceIDKey: {
+							Description: "Instance identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ServiceKey: {
+							Description: "Name of the sidecar service.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ComponentKey: {
+							Description: "Name of the monitored component of the service.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						utils.StatusKey: {
+							Description: "Status of the component, or of the service if it has no components.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						ErrorKey: {
+							Description: "Error that describes what caused the current status.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			SidecarCountKey: {
+				Description: "Number of sidecars considered.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			TotalInstanceCountKey: {
+				Description: "Number of instances of all sidecars considered.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			FailingComponentCountKey: {
+				Description: "Number of components whose status is not `" + health.StatusHealthy + "`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			SidecarStatusCountsKey:   statusCountsSchema("Number of sidecars per aggregated health status."),
+			InstanceStatusCountsKey:  statusCountsSchema("Number of instances per status."),
+			ComponentStatusCountsKey: statusCountsSchema("Number of components per status."),
+		},
+	}
+}
+
+func dataSourceSidecarFleetHealthRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceSidecarFleetHealthRead")
+	c := m.(*client.Client)
+	labels := utils.ConvertFromInterfaceList[string](d.Get(LabelsKey).([]interface{}))
+	labelsMatch := d.Get(LabelsMatchKey).(string)
+
+	sidecarsInfo, err := sidecar.ListSidecars(c)
+	if err != nil {
+		return utils.CreateError("Unable to retrieve the list of existent sidecars.", err.Error())
+	}
+
+	fleetHealth := &FleetHealth{OnlyFailing: d.Get(OnlyFailingKey).(bool)}
+	for _, sidecarInfo := range sidecarsInfo {
+		if !utils.MatchLabels(sidecarInfo.Sidecar.Labels, labels, labelsMatch) {
+			continue
+		}
+		sidecarHealth, err := getSidecarHealth(ctx, c, sidecarInfo)
+		if err != nil {
+			return utils.CreateError(
+				fmt.Sprintf("Unable to retrieve the health of sidecar %q", sidecarInfo.ID), err.Error())
+		}
+		fleetHealth.Sidecars = append(fleetHealth.Sidecars, *sidecarHealth)
+	}
+	sort.SliceStable(fleetHealth.Sidecars, func(i, j int) bool {
+		return fleetHealth.Sidecars[i].Name < fleetHealth.Sidecars[j].Name
+	})
+
+	if err := fleetHealth.WriteToSchema(d); err != nil {
+		return utils.CreateError("Unable to read sidecar fleet health", err.Error())
+	}
+	d.SetId(uuid.New().String())
+
+	tflog.Debug(ctx, "End dataSourceSidecarFleetHealthRead")
+	return nil
+}
+
+// getSidecarHealth retrieves the health of the sidecar and of its instances.
+// Sidecars that have never reported their health are returned with an
+// unknown status and without instances.
+func getSidecarHealth(
+	ctx context.Context,
+	c *client.Client,
+	sidecarInfo sidecar.IdentifiedSidecarInfo,
+) (*SidecarHealth, error) {
+	sidecarHealth := &SidecarHealth{
+		SidecarID: sidecarInfo.ID,
+		Name:      sidecarInfo.Sidecar.Name,
+		Labels:    sidecarInfo.Sidecar.Labels,
+		Status:    health.StatusUnknown,
+	}
+	status, err := health.GetSidecarHealth(ctx, c, sidecarInfo.ID)
+	if err != nil && !client.IsNotFound(err) {
+		return nil, err
+	}
+	if status != nil {
+		sidecarHealth.Status = status.Status
+	}
+	instances, err := instance.ListSidecarInstances(ctx, c, sidecarInfo.ID)
+	if err != nil && !client.IsNotFound(err) {
+		return nil, err
+	}
+	sidecarHealth.Instances = instances
+	return sidecarHealth, nil
+}
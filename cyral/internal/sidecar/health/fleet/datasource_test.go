@@ -0,0 +1,110 @@
+package fleet_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	sidecarFleetHealthDataSourceFullNameFmt = "data.cyral_sidecar_fleet_health.%s"
+)
+
+func TestAccSidecarFleetHealthDataSource(t *testing.T) {
+	dataSourceName := "sidecar_fleet_health"
+	testSteps := []resource.TestStep{
+		accTestStepSidecarFleetHealthDataSource_FilterByLabels(dataSourceName),
+		accTestStepSidecarFleetHealthDataSource_NoMatchingLabels(dataSourceName),
+		accTestStepSidecarFleetHealthDataSource_AnyMatchingLabel(dataSourceName),
+	}
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps:             testSteps,
+	})
+}
+
+func sidecarFleetHealthSidecarConfig(label string) string {
+	return fmt.Sprintf(`
+		resource "cyral_sidecar" "%s" {
+			name              = "%s"
+			deployment_method = "docker"
+			labels            = ["%s"]
+		}
+	`, utils.BasicSidecarResName, utils.AccTestName("data-sidecar-fleet-health", "sidecar"), label)
+}
+
+func accTestStepSidecarFleetHealthDataSource_FilterByLabels(dataSourceName string) resource.TestStep {
+	label := utils.AccTestName("data-sidecar-fleet-health", "label")
+	config := sidecarFleetHealthSidecarConfig(label)
+	config += fmt.Sprintf(`
+		data "cyral_sidecar_fleet_health" "%s" {
+			labels     = ["%s"]
+			depends_on = [cyral_sidecar.%s]
+		}
+	`, dataSourceName, label, utils.BasicSidecarResName)
+	dataSourceFullName := fmt.Sprintf(sidecarFleetHealthDataSourceFullNameFmt, dataSourceName)
+	check := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_count", "1"),
+		resource.TestCheckResourceAttrPair(
+			dataSourceFullName, "sidecars.0.sidecar_id",
+			fmt.Sprintf("cyral_sidecar.%s", utils.BasicSidecarResName), "id",
+		),
+		resource.TestCheckResourceAttr(dataSourceFullName, "sidecars.0.status", "UNKNOWN"),
+		resource.TestCheckResourceAttr(dataSourceFullName, "sidecars.0.instance_count", "0"),
+		resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_status_counts.UNKNOWN", "1"),
+		resource.TestCheckResourceAttr(dataSourceFullName, "total_instance_count", "0"),
+		resource.TestCheckResourceAttr(dataSourceFullName, "components.#", "0"),
+		resource.TestCheckResourceAttr(dataSourceFullName, "failing_component_count", "0"),
+	)
+	return resource.TestStep{
+		Config: config,
+		Check:  check,
+	}
+}
+
+func accTestStepSidecarFleetHealthDataSource_NoMatchingLabels(dataSourceName string) resource.TestStep {
+	label := utils.AccTestName("data-sidecar-fleet-health", "label")
+	config := sidecarFleetHealthSidecarConfig(label)
+	config += fmt.Sprintf(`
+		data "cyral_sidecar_fleet_health" "%s" {
+			labels     = ["%s", "%s"]
+			depends_on = [cyral_sidecar.%s]
+		}
+	`, dataSourceName, label, utils.AccTestName("data-sidecar-fleet-health", "other-label"),
+		utils.BasicSidecarResName)
+	dataSourceFullName := fmt.Sprintf(sidecarFleetHealthDataSourceFullNameFmt, dataSourceName)
+	return resource.TestStep{
+		Config: config,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_count", "0"),
+			resource.TestCheckResourceAttr(dataSourceFullName, "sidecars.#", "0"),
+		),
+	}
+}
+
+func accTestStepSidecarFleetHealthDataSource_AnyMatchingLabel(dataSourceName string) resource.TestStep {
+	label := utils.AccTestName("data-sidecar-fleet-health", "label")
+	config := sidecarFleetHealthSidecarConfig(label)
+	config += fmt.Sprintf(`
+		data "cyral_sidecar_fleet_health" "%s" {
+			labels       = ["%s", "%s"]
+			labels_match = "any"
+			depends_on   = [cyral_sidecar.%s]
+		}
+	`, dataSourceName, label, utils.AccTestName("data-sidecar-fleet-health", "other-label"),
+		utils.BasicSidecarResName)
+	dataSourceFullName := fmt.Sprintf(sidecarFleetHealthDataSourceFullNameFmt, dataSourceName)
+	return resource.TestStep{
+		Config: config,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(dataSourceFullName, "sidecar_count", "1"),
+			resource.TestCheckResourceAttrPair(
+				dataSourceFullName, "sidecars.0.sidecar_id",
+				fmt.Sprintf("cyral_sidecar.%s", utils.BasicSidecarResName), "id",
+			),
+		),
+	}
+}
@@ -0,0 +1,157 @@
+package fleet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// SidecarHealth is the health of a sidecar and of all its instances.
+type SidecarHealth struct {
+	SidecarID string
+	Name      string
+	Labels    []string
+	Status    string
+	Instances []instance.SidecarInstance
+}
+
+// ComponentHealth is the status of a single component of a service running in
+// a sidecar instance. Services without components are represented by a single
+// row with an empty component.
+type ComponentHealth struct {
+	SidecarID   string
+	SidecarName string
+	InstanceID  string
+	Service     string
+	Component   string
+	Status      string
+	Error       string
+}
+
+func (c *ComponentHealth) isFailing() bool {
+	return c.Status != health.StatusHealthy
+}
+
+func (c *ComponentHealth) ToMap() map[string]any {
+	return map[string]any{
+		utils.SidecarIDKey: c.SidecarID,
+		SidecarNameKey:     c.SidecarName,
+		InstanceIDKey:      c.InstanceID,
+		ServiceKey:         c.Service,
+		ComponentKey:       c.Component,
+		utils.StatusKey:    c.Status,
+		ErrorKey:           c.Error,
+	}
+}
+
+// components flattens the monitoring information of the sidecar instances
+// into one row per component, sorted by instance, service and component.
+func (s *SidecarHealth) components() []ComponentHealth {
+	var components []ComponentHealth
+	for _, inst := range s.Instances {
+		for serviceName, service := range inst.Monitoring.Services {
+			if len(service.Components) == 0 {
+				components = append(components, ComponentHealth{
+					SidecarID:   s.SidecarID,
+					SidecarName: s.Name,
+					InstanceID:  inst.ID,
+					Service:     serviceName,
+					Status:      health.StatusOrUnknown(service.Status),
+				})
+				continue
+			}
+			for componentName, component := range service.Components {
+				components = append(components, ComponentHealth{
+					SidecarID:   s.SidecarID,
+					SidecarName: s.Name,
+					InstanceID:  inst.ID,
+					Service:     serviceName,
+					Component:   componentName,
+					Status:      health.StatusOrUnknown(component.Status),
+					Error:       component.Error,
+				})
+			}
+		}
+	}
+	sort.Slice(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		if a.InstanceID != b.InstanceID {
+			return a.InstanceID < b.InstanceID
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Component < b.Component
+	})
+	return components
+}
+
+func (s *SidecarHealth) ToMap() map[string]any {
+	return map[string]any{
+		utils.SidecarIDKey:      s.SidecarID,
+		utils.NameKey:           s.Name,
+		LabelsKey:               s.Labels,
+		utils.StatusKey:         health.StatusOrUnknown(s.Status),
+		InstanceCountKey:        len(s.Instances),
+		HealthyInstanceCountKey: health.HealthyInstances(s.Instances),
+	}
+}
+
+// FleetHealth is the health of a set of sidecars, sorted by name.
+type FleetHealth struct {
+	Sidecars    []SidecarHealth
+	OnlyFailing bool
+}
+
+func (f *FleetHealth) WriteToSchema(d *schema.ResourceData) error {
+	sidecars := make([]any, 0, len(f.Sidecars))
+	components := make([]any, 0)
+	sidecarStatusCounts := make(map[string]any)
+	instanceStatusCounts := make(map[string]any)
+	componentStatusCounts := make(map[string]any)
+	increment := func(counts map[string]any, status string) {
+		count, _ := counts[status].(int)
+		counts[status] = count + 1
+	}
+	totalInstances, failingComponents := 0, 0
+
+	for _, sidecar := range f.Sidecars {
+		sidecars = append(sidecars, sidecar.ToMap())
+		increment(sidecarStatusCounts, health.StatusOrUnknown(sidecar.Status))
+		totalInstances += len(sidecar.Instances)
+		for _, inst := range sidecar.Instances {
+			increment(instanceStatusCounts, health.StatusOrUnknown(inst.Monitoring.Status))
+		}
+		for _, component := range sidecar.components() {
+			increment(componentStatusCounts, component.Status)
+			if component.isFailing() {
+				failingComponents++
+			} else if f.OnlyFailing {
+				continue
+			}
+			components = append(components, component.ToMap())
+		}
+	}
+
+	fields := map[string]any{
+		SidecarsKey:              sidecars,
+		ComponentsKey:            components,
+		SidecarCountKey:          len(f.Sidecars),
+		TotalInstanceCountKey:    totalInstances,
+		FailingComponentCountKey: failingComponents,
+		SidecarStatusCountsKey:   sidecarStatusCounts,
+		InstanceStatusCountsKey:  instanceStatusCounts,
+		ComponentStatusCountsKey: componentStatusCounts,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf(utils.ErrorSettingFieldFmt, key, err)
+		}
+	}
+	return nil
+}
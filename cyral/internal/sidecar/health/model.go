@@ -1,8 +1,16 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -16,3 +24,39 @@ func (health *SidecarHealth) WriteToSchema(d *schema.ResourceData) error {
 
 	return nil
 }
+
+// GetSidecarHealth retrieves the aggregated health of the sidecar instances.
+func GetSidecarHealth(ctx context.Context, c *client.Client, sidecarID string) (*SidecarHealth, error) {
+	tflog.Debug(ctx, "Init GetSidecarHealth")
+	url := fmt.Sprintf("https://%s/v2/sidecars/%s/health", c.ControlPlane, sidecarID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	health := &SidecarHealth{}
+	if err := json.Unmarshal(body, health); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshaled): %#v", health))
+	tflog.Debug(ctx, "End GetSidecarHealth")
+	return health, nil
+}
+
+// HealthyInstances returns the number of instances whose status is healthy.
+func HealthyInstances(instances []instance.SidecarInstance) int {
+	healthy := 0
+	for _, inst := range instances {
+		if inst.Monitoring.Status == StatusHealthy {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// StatusOrUnknown returns the given status, or StatusUnknown if it is empty.
+func StatusOrUnknown(status string) string {
+	if status == "" {
+		return StatusUnknown
+	}
+	return status
+}
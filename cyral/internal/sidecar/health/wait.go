@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// WaitForHealthySchema returns the schema of the `wait_for_healthy` block,
+// shared by the resources that can wait for the sidecar to become healthy
+// after being changed. The operations argument describes the operations that
+// wait, such as `create and update`.
+func WaitForHealthySchema(operations string) *schema.Schema {
+	return &schema.Schema{
+		Description: "If set, the " + operations + " operations wait until the sidecar is healthy, i.e. its " +
+			"aggregated health status is `" + StatusHealthy + "` and at least `" + MinInstancesKey + "` of its " +
+			"instances are registered and report `" + StatusHealthy + "`. The operation fails with the status of " +
+			"each instance if the sidecar is not healthy within the timeout of the operation, which defaults to " +
+			fmt.Sprintf("`%s` and can be changed in the `timeouts` block.", DefaultWaitTimeout),
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				MinInstancesKey: {
+					Description: fmt.Sprintf("Minimum number of healthy instances. Defaults to `%d`.",
+						defaultWaitMinInstances),
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultWaitMinInstances,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+type WaitForHealthyConfig struct {
+	Timeout      time.Duration
+	MinInstances int
+}
+
+// readWaitForHealthyConfig reads the `wait_for_healthy` block and the timeout
+// of the current operation. It returns nil if the block is not set.
+func readWaitForHealthyConfig(d *schema.ResourceData) *WaitForHealthyConfig {
+	blocks := d.Get(WaitForHealthyKey).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	return &WaitForHealthyConfig{
+		Timeout:      timeout,
+		MinInstances: block[MinInstancesKey].(int),
+	}
+}
+
+// healthSnapshot is the health of a sidecar and of its instances at a given
+// point in time.
+type healthSnapshot struct {
+	status    string
+	instances []instance.SidecarInstance
+}
+
+func (s *healthSnapshot) isHealthy(minInstances int) bool {
+	return s.status == StatusHealthy && HealthyInstances(s.instances) >= minInstances
+}
+
+// describe returns a human-readable description of the sidecar health,
+// including the status of each instance.
+func (s *healthSnapshot) describe(minInstances int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Sidecar status: %s. Healthy instances: %d (required: %d).",
+		s.status, HealthyInstances(s.instances), minInstances))
+	if len(s.instances) == 0 {
+		sb.WriteString("\nNo instances are registered.")
+		return sb.String()
+	}
+	sb.WriteString("\nInstances:")
+	for _, inst := range s.instances {
+		sb.WriteString(fmt.Sprintf("\n  - %s: %s", inst.ID, StatusOrUnknown(inst.Monitoring.Status)))
+		if inst.Metadata.Version != "" {
+			sb.WriteString(fmt.Sprintf(" (version %s)", inst.Metadata.Version))
+		}
+		serviceNames := make([]string, 0, len(inst.Monitoring.Services))
+		for serviceName := range inst.Monitoring.Services {
+			serviceNames = append(serviceNames, serviceName)
+		}
+		sort.Strings(serviceNames)
+		for _, serviceName := range serviceNames {
+			if status := inst.Monitoring.Services[serviceName].Status; status != "" && status != StatusHealthy {
+				sb.WriteString(fmt.Sprintf("\n      service %s: %s", serviceName, status))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// getHealthSnapshot retrieves the health of the sidecar and of its instances.
+// Sidecars whose health or instances are not found yet are reported as
+// unknown and without instances, respectively.
+func getHealthSnapshot(ctx context.Context, c *client.Client, sidecarID string) (*healthSnapshot, error) {
+	snapshot := &healthSnapshot{status: StatusUnknown}
+	health, err := GetSidecarHealth(ctx, c, sidecarID)
+	if err != nil && !client.IsNotFound(err) {
+		return nil, err
+	}
+	if health != nil && health.Status != "" {
+		snapshot.status = health.Status
+	}
+	instances, err := instance.ListSidecarInstances(ctx, c, sidecarID)
+	if err != nil && !client.IsNotFound(err) {
+		return nil, err
+	}
+	snapshot.instances = instances
+	return snapshot, nil
+}
+
+// WaitForHealthy polls the health of the sidecar and of its instances until
+// the sidecar is healthy or the timeout expires.
+func WaitForHealthy(ctx context.Context, c *client.Client, sidecarID string, config WaitForHealthyConfig) diag.Diagnostics {
+	tflog.Debug(ctx, "Init WaitForHealthy")
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	// Last snapshot retrieved before the timeout, used to report the status
+	// of the instances if the sidecar does not become healthy.
+	var snapshot *healthSnapshot
+	for {
+		current, err := getHealthSnapshot(ctx, c, sidecarID)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			return utils.CreateError(fmt.Sprintf("Unable to retrieve the health of sidecar %q", sidecarID),
+				err.Error())
+		case err == nil:
+			snapshot = current
+			if snapshot.isHealthy(config.MinInstances) {
+				tflog.Debug(ctx, "End WaitForHealthy")
+				return nil
+			}
+			tflog.Debug(ctx, snapshot.describe(config.MinInstances))
+		}
+		select {
+		case <-ctx.Done():
+			detail := "The health of the sidecar could not be retrieved."
+			if snapshot != nil {
+				detail = snapshot.describe(config.MinInstances)
+			}
+			return utils.CreateError(
+				fmt.Sprintf("Sidecar %q did not become healthy within %s", sidecarID, config.Timeout),
+				detail,
+			)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithWaitForHealthy waits for the sidecar to become healthy after the given
+// create or update function succeeds, if the `wait_for_healthy` block is set.
+// The wait is bounded by the timeout of the operation. Updates that only change
+// the `wait_for_healthy` block do not wait.
+func WithWaitForHealthy(
+	sidecarID func(*schema.ResourceData) string,
+	f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := f(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+		if !d.IsNewResource() && !d.HasChangesExcept(WaitForHealthyKey) {
+			return diags
+		}
+		config := readWaitForHealthyConfig(d)
+		if config == nil {
+			return diags
+		}
+		return append(diags, WaitForHealthy(ctx, m.(*client.Client), sidecarID(d), *config)...)
+	}
+}
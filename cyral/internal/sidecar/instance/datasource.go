@@ -1,6 +1,8 @@
 package instance
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -8,9 +10,27 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/operationtype"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ListSidecarInstances retrieves all the instances of the sidecar.
+func ListSidecarInstances(ctx context.Context, c *client.Client, sidecarID string) ([]SidecarInstance, error) {
+	tflog.Debug(ctx, "Init ListSidecarInstances")
+	url := fmt.Sprintf("https://%s/v2/sidecars/%s/instances", c.ControlPlane, sidecarID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := SidecarInstances{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Found %d instances", len(resp.Instances)))
+	tflog.Debug(ctx, "End ListSidecarInstances")
+	return resp.Instances, nil
+}
+
 func dataSourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: "Retrieve sidecar instances.",
@@ -1,7 +1,8 @@
 package stats
 
 const (
-	dataSourceName = "cyral_sidecar_instance_stats"
+	dataSourceName          = "cyral_sidecar_instance_stats"
+	instancesDataSourceName = "cyral_sidecar_instances_stats"
 )
 
 const (
@@ -9,4 +10,10 @@ const (
 	InstanceIDKey        = "instance_id"
 	QueriesPerSecondKey  = "queries_per_second"
 	ActiveConnectionsKey = "active_connections"
+	InstanceCountKey     = "instance_count"
+	InstanceStatsKey     = "instance_stats"
+	MinKey               = "min"
+	AvgKey               = "avg"
+	MaxKey               = "max"
+	TotalKey             = "total"
 )
@@ -0,0 +1,163 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func summarySchema(description string, valueType schema.ValueType) *schema.Schema {
+	return &schema.Schema{
+		Description: description + " Empty if the sidecar has no instances with statistics.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				MinKey: {
+					Description: "Lowest value among the instances.",
+					Type:        valueType,
+					Computed:    true,
+				},
+				AvgKey: {
+					Description: "Average value of the instances.",
+					Type:        schema.TypeFloat,
+					Computed:    true,
+				},
+				MaxKey: {
+					Description: "Highest value among the instances.",
+					Type:        valueType,
+					Computed:    true,
+				},
+				TotalKey: {
+					Description: "Sum of the values of all the instances.",
+					Type:        valueType,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func instancesDataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieve the statistics of all the instances of a sidecar, along with a summary over the " +
+			"instances, which can be used to drive scaling decisions or `check` blocks. The statistics are the " +
+			"current values reported by each instance. See also data source " +
+			"[`cyral_sidecar_instance_stats`](../data-sources/sidecar_instance_stats.md).",
+		ReadContext: dataSourceSidecarInstancesStatsRead,
+		Schema: map[string]*schema.Schema{
+			utils.SidecarIDKey: {
+				Description: "Sidecar identifier.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			utils.IDKey: {
+				Description: fmt.Sprintf("Data source identifier. It's equal to `%s`.", utils.SidecarIDKey),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			InstanceCountKey: {
+				Description: "Number of instances of the sidecar with statistics, which are the instances " +
+					"included in the summaries. Instances that are terminated while the statistics are " +
+					"retrieved are not counted.",
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			InstanceStatsKey: {
+				Description: "Statistics of each instance of the sidecar, sorted by instance identifier. " +
+					"Instances that are terminated while the statistics are retrieved are omitted.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						InstanceIDKey: {
+							Description: "Sidecar instance identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						QueriesPerSecondKey: {
+							Description: "Amount of queries that the sidecar instance receives per second.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						ActiveConnectionsKey: {
+							Description: "Number of active connections.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			QueriesPerSecondKey: summarySchema(
+				"Summary of the amount of queries per second received by the instances.", schema.TypeFloat,
+			),
+			ActiveConnectionsKey: summarySchema(
+				"Summary of the number of active connections of the instances.", schema.TypeInt,
+			),
+		},
+	}
+}
+
+func dataSourceSidecarInstancesStatsRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceSidecarInstancesStatsRead")
+	c := m.(*client.Client)
+	sidecarID := d.Get(utils.SidecarIDKey).(string)
+
+	instances, err := instance.ListSidecarInstances(ctx, c, sidecarID)
+	if err != nil {
+		return utils.CreateError("Unable to list sidecar instances", err.Error())
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID < instances[j].ID
+	})
+
+	var instanceStats []any
+	queriesPerSecond, activeConnections := &statsSummary{}, &statsSummary{}
+	for _, sidecarInstance := range instances {
+		stats, err := GetSidecarInstanceStats(ctx, c, sidecarID, sidecarInstance.ID)
+		if err != nil {
+			// The instance may have been terminated after the instances
+			// were listed.
+			if client.IsNotFound(err) {
+				tflog.Debug(ctx, fmt.Sprintf("Skipping instance %q: %v", sidecarInstance.ID, err))
+				continue
+			}
+			return utils.CreateError(
+				fmt.Sprintf("Unable to retrieve statistics of sidecar instance %q", sidecarInstance.ID),
+				err.Error(),
+			)
+		}
+		instanceStats = append(instanceStats, stats.ToMap(sidecarInstance.ID))
+		queriesPerSecond.Add(float64(stats.QueriesPerSecond))
+		activeConnections.Add(float64(stats.ActiveConnections))
+	}
+
+	values := map[string]any{
+		InstanceCountKey:     len(instanceStats),
+		InstanceStatsKey:     instanceStats,
+		QueriesPerSecondKey:  queriesPerSecond.ToInterfaceList(false),
+		ActiveConnectionsKey: activeConnections.ToInterfaceList(true),
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return utils.CreateError("Unable to read sidecar instances statistics",
+				fmt.Errorf(utils.ErrorSettingFieldFmt, key, err).Error())
+		}
+	}
+	d.SetId(sidecarID)
+
+	tflog.Debug(ctx, "End dataSourceSidecarInstancesStatsRead")
+	return nil
+}
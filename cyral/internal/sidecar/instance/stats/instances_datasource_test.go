@@ -0,0 +1,59 @@
+package stats_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance/stats"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSidecarInstancesStatsDataSource(t *testing.T) {
+	dataSourceName := "instances_stats"
+	dataSourceFullName := fmt.Sprintf("data.cyral_sidecar_instances_stats.%s", dataSourceName)
+
+	// Creates a sidecar that doesn't have any instances, since it was not
+	// deployed.
+	noInstancesConfig := utils.FormatBasicSidecarIntoConfig(
+		utils.BasicSidecarResName,
+		utils.AccTestName("data-sidecar-instances-stats", "sidecar"),
+		"cft-ec2",
+		"",
+	)
+	noInstancesConfig += fmt.Sprintf(`
+	data "cyral_sidecar_instances_stats" "%s" {
+		sidecar_id = %s
+	}
+	`, dataSourceName, utils.BasicSidecarID)
+
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+					data "cyral_sidecar_instances_stats" "%s" {
+					}
+					`, dataSourceName),
+					ExpectError: regexp.MustCompile(fmt.Sprintf(`The argument "%s" is required`, utils.SidecarIDKey)),
+				},
+				{
+					Config: noInstancesConfig,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair(
+							dataSourceFullName, utils.IDKey,
+							fmt.Sprintf("cyral_sidecar.%s", utils.BasicSidecarResName), utils.IDKey,
+						),
+						resource.TestCheckResourceAttr(dataSourceFullName, stats.InstanceCountKey, "0"),
+						resource.TestCheckResourceAttr(dataSourceFullName, stats.InstanceStatsKey+".#", "0"),
+						resource.TestCheckResourceAttr(dataSourceFullName, stats.QueriesPerSecondKey+".#", "0"),
+						resource.TestCheckResourceAttr(dataSourceFullName, stats.ActiveConnectionsKey+".#", "0"),
+					),
+				},
+			},
+		},
+	)
+}
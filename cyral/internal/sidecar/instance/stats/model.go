@@ -1,7 +1,15 @@
 package stats
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 )
 
 type SidecarInstanceStats struct {
@@ -16,3 +24,80 @@ func (stats *SidecarInstanceStats) WriteToSchema(d *schema.ResourceData) error {
 
 	return nil
 }
+
+func (stats *SidecarInstanceStats) ToMap(instanceID string) map[string]any {
+	return map[string]any{
+		InstanceIDKey:        instanceID,
+		QueriesPerSecondKey:  stats.QueriesPerSecond,
+		ActiveConnectionsKey: stats.ActiveConnections,
+	}
+}
+
+// GetSidecarInstanceStats retrieves the current statistics of a sidecar
+// instance.
+func GetSidecarInstanceStats(
+	ctx context.Context,
+	c *client.Client,
+	sidecarID, instanceID string,
+) (*SidecarInstanceStats, error) {
+	tflog.Debug(ctx, "Init GetSidecarInstanceStats")
+	url := fmt.Sprintf("https://%s/v2/sidecars/%s/instances/%s/stats", c.ControlPlane, sidecarID, instanceID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	stats := &SidecarInstanceStats{}
+	if err := json.Unmarshal(body, stats); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshaled): %#v", stats))
+	tflog.Debug(ctx, "End GetSidecarInstanceStats")
+	return stats, nil
+}
+
+// statsSummary aggregates a statistic over the instances of a sidecar.
+type statsSummary struct {
+	Min   float64
+	Max   float64
+	Total float64
+	Count int
+}
+
+func (s *statsSummary) Add(value float64) {
+	if s.Count == 0 || value < s.Min {
+		s.Min = value
+	}
+	if s.Count == 0 || value > s.Max {
+		s.Max = value
+	}
+	s.Total += value
+	s.Count++
+}
+
+func (s *statsSummary) Avg() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / float64(s.Count)
+}
+
+// ToInterfaceList returns the summary as a single-element list, or an empty
+// list if no value was added. If integer is true, min, max and total are
+// returned as integers.
+func (s *statsSummary) ToInterfaceList(integer bool) []any {
+	if s.Count == 0 {
+		return nil
+	}
+	summary := map[string]any{
+		MinKey:   s.Min,
+		AvgKey:   s.Avg(),
+		MaxKey:   s.Max,
+		TotalKey: s.Total,
+	}
+	if integer {
+		summary[MinKey] = int(s.Min)
+		summary[MaxKey] = int(s.Max)
+		summary[TotalKey] = int(s.Total)
+	}
+	return []any{summary}
+}
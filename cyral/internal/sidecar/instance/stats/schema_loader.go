@@ -18,6 +18,11 @@ func (p *packageSchema) Schemas() []*core.SchemaDescriptor {
 			Type:   core.DataSourceSchemaType,
 			Schema: dataSourceSchema,
 		},
+		{
+			Name:   instancesDataSourceName,
+			Type:   core.DataSourceSchemaType,
+			Schema: instancesDataSourceSchema,
+		},
 	}
 }
 
@@ -1,5 +1,9 @@
 package listener
 
+import (
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
 // Resource
 const (
 	resourceName   = "cyral_sidecar_listener"
@@ -15,6 +19,20 @@ const (
 	DynamoDbSettingsKey  = "dynamodb_settings"
 	SQLServerSettingsKey = "sqlserver_settings"
 	SQLServerVersionKey  = "version"
+
+	PostgreSQLSettingsKey      = "postgresql_settings"
+	TLSModeKey                 = "tls_mode"
+	WireProtocolVersionKey     = "wire_protocol_version"
+	OracleSettingsKey          = "oracle_settings"
+	ServiceNameMappingsKey     = "service_name_mappings"
+	NativeNetworkEncryptionKey = "native_network_encryption"
+	MongoDBSettingsKey         = "mongodb_settings"
+	WireCompressionKey         = "wire_compression"
+	SnowflakeSettingsKey       = "snowflake_settings"
+	RewriteAccountHostKey      = "rewrite_account_host"
+
+	// Host the sidecar listens on when `host` is omitted.
+	defaultListenerHost = "0.0.0.0"
 )
 
 // Data source
@@ -22,3 +40,97 @@ const (
 	SidecarListenerListKey = "listener_list"
 	DSRepoTypeKey          = "repo_type"
 )
+
+type PostgreSQLTLSMode string
+
+const (
+	PostgreSQLTLSDisable = PostgreSQLTLSMode("disable")
+	PostgreSQLTLSPrefer  = PostgreSQLTLSMode("prefer")
+	PostgreSQLTLSRequire = PostgreSQLTLSMode("require")
+)
+
+func PostgreSQLTLSModes() []PostgreSQLTLSMode {
+	return []PostgreSQLTLSMode{
+		PostgreSQLTLSDisable,
+		PostgreSQLTLSPrefer,
+		PostgreSQLTLSRequire,
+	}
+}
+
+func PostgreSQLTLSModesAsString() []string {
+	return utils.ToSliceOfString[PostgreSQLTLSMode](PostgreSQLTLSModes(), func(m PostgreSQLTLSMode) string {
+		return string(m)
+	})
+}
+
+type PostgreSQLWireProtocolVersion string
+
+const (
+	PostgreSQLWireProtocol30 = PostgreSQLWireProtocolVersion("3.0")
+	PostgreSQLWireProtocol32 = PostgreSQLWireProtocolVersion("3.2")
+)
+
+func PostgreSQLWireProtocolVersions() []PostgreSQLWireProtocolVersion {
+	return []PostgreSQLWireProtocolVersion{
+		PostgreSQLWireProtocol30,
+		PostgreSQLWireProtocol32,
+	}
+}
+
+func PostgreSQLWireProtocolVersionsAsString() []string {
+	return utils.ToSliceOfString[PostgreSQLWireProtocolVersion](
+		PostgreSQLWireProtocolVersions(),
+		func(v PostgreSQLWireProtocolVersion) string {
+			return string(v)
+		},
+	)
+}
+
+type OracleNativeNetworkEncryption string
+
+const (
+	OracleEncryptionRejected  = OracleNativeNetworkEncryption("REJECTED")
+	OracleEncryptionAccepted  = OracleNativeNetworkEncryption("ACCEPTED")
+	OracleEncryptionRequested = OracleNativeNetworkEncryption("REQUESTED")
+	OracleEncryptionRequired  = OracleNativeNetworkEncryption("REQUIRED")
+)
+
+func OracleNativeNetworkEncryptions() []OracleNativeNetworkEncryption {
+	return []OracleNativeNetworkEncryption{
+		OracleEncryptionRejected,
+		OracleEncryptionAccepted,
+		OracleEncryptionRequested,
+		OracleEncryptionRequired,
+	}
+}
+
+func OracleNativeNetworkEncryptionsAsString() []string {
+	return utils.ToSliceOfString[OracleNativeNetworkEncryption](
+		OracleNativeNetworkEncryptions(),
+		func(e OracleNativeNetworkEncryption) string {
+			return string(e)
+		},
+	)
+}
+
+type MongoDBCompressor string
+
+const (
+	MongoDBCompressorSnappy = MongoDBCompressor("snappy")
+	MongoDBCompressorZlib   = MongoDBCompressor("zlib")
+	MongoDBCompressorZstd   = MongoDBCompressor("zstd")
+)
+
+func MongoDBCompressors() []MongoDBCompressor {
+	return []MongoDBCompressor{
+		MongoDBCompressorSnappy,
+		MongoDBCompressorZlib,
+		MongoDBCompressorZstd,
+	}
+}
+
+func MongoDBCompressorsAsString() []string {
+	return utils.ToSliceOfString[MongoDBCompressor](MongoDBCompressors(), func(c MongoDBCompressor) string {
+		return string(c)
+	})
+}
@@ -34,6 +34,9 @@ func listenerDataSourceTestRepos() []listener.SidecarListener {
 			NetworkAddress: &listener.NetworkAddress{
 				Port: 27018,
 			},
+			MongoDBSettings: &listener.MongoDBSettings{
+				WireCompression: []string{"zstd"},
+			},
 		},
 	}
 }
@@ -112,6 +115,15 @@ func testListenerDataSourceChecks(listeners []listener.SidecarListener, repoType
 		resource.TestCheckResourceAttr(dataSourceFullName,
 			"listener_list.#", fmt.Sprintf("%d", len(filteredListeners))),
 	}...)
+	for _, l := range filteredListeners {
+		if l.MongoDBSettings != nil {
+			checkFuncs = append(checkFuncs, resource.TestCheckTypeSetElemNestedAttrs(
+				dataSourceFullName, "listener_list.*", map[string]string{
+					"mongodb_settings.0.wire_compression.0": l.MongoDBSettings.WireCompression[0],
+				},
+			))
+		}
+	}
 
 	return resource.ComposeTestCheckFunc(checkFuncs...)
 }
@@ -2,26 +2,36 @@ package listener
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/exp/slices"
 
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 // SidecarListener struct for sidecar listener.
 type SidecarListener struct {
-	SidecarId         string             `json:"-"`
-	ListenerId        string             `json:"id"`
-	RepoTypes         []string           `json:"repoTypes"`
-	NetworkAddress    *NetworkAddress    `json:"address,omitempty"`
-	MySQLSettings     *MySQLSettings     `json:"mysqlSettings,omitempty"`
-	S3Settings        *S3Settings        `json:"s3Settings,omitempty"`
-	DynamoDbSettings  *DynamoDbSettings  `json:"dynamoDbSettings,omitempty"`
-	SQLServerSettings *SQLServerSettings `json:"sqlServerSettings,omitempty"`
+	SidecarId          string              `json:"-"`
+	ListenerId         string              `json:"id"`
+	RepoTypes          []string            `json:"repoTypes"`
+	NetworkAddress     *NetworkAddress     `json:"address,omitempty"`
+	MySQLSettings      *MySQLSettings      `json:"mysqlSettings,omitempty"`
+	S3Settings         *S3Settings         `json:"s3Settings,omitempty"`
+	DynamoDbSettings   *DynamoDbSettings   `json:"dynamoDbSettings,omitempty"`
+	SQLServerSettings  *SQLServerSettings  `json:"sqlServerSettings,omitempty"`
+	PostgreSQLSettings *PostgreSQLSettings `json:"postgresqlSettings,omitempty"`
+	OracleSettings     *OracleSettings     `json:"oracleSettings,omitempty"`
+	MongoDBSettings    *MongoDBSettings    `json:"mongodbSettings,omitempty"`
+	SnowflakeSettings  *SnowflakeSettings  `json:"snowflakeSettings,omitempty"`
 }
 
 type NetworkAddress struct {
@@ -29,6 +39,39 @@ type NetworkAddress struct {
 	Port int    `json:"port"`
 }
 
+// String returns the address as `host:port`, using `0.0.0.0` when the host
+// is omitted. IPv6 hosts are enclosed in square brackets.
+func (a NetworkAddress) String() string {
+	host := a.Host
+	if host == "" {
+		host = defaultListenerHost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(a.Port))
+}
+
+// Overlaps returns true if both addresses use the same port and either one
+// of them listens on all network interfaces, such as `0.0.0.0` or `::`, or
+// both use the same host.
+func (a NetworkAddress) Overlaps(other NetworkAddress) bool {
+	if a.Port != other.Port {
+		return false
+	}
+	isAllInterfaces := func(host string) bool {
+		if host == "" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsUnspecified()
+	}
+	if isAllInterfaces(a.Host) || isAllInterfaces(other.Host) {
+		return true
+	}
+	if ip, otherIP := net.ParseIP(a.Host), net.ParseIP(other.Host); ip != nil && otherIP != nil {
+		return ip.Equal(otherIP)
+	}
+	return strings.EqualFold(a.Host, other.Host)
+}
+
 type MySQLSettings struct {
 	DbVersion    string `json:"dbVersion,omitempty"`
 	CharacterSet string `json:"characterSet,omitempty"`
@@ -46,6 +89,26 @@ type SQLServerSettings struct {
 	Version string `json:"version,omitempty"`
 }
 
+type PostgreSQLSettings struct {
+	TLSMode             string `json:"tlsMode,omitempty"`
+	WireProtocolVersion string `json:"wireProtocolVersion,omitempty"`
+}
+
+type OracleSettings struct {
+	ServiceNameMappings     map[string]string `json:"serviceNameMappings,omitempty"`
+	NativeNetworkEncryption string            `json:"nativeNetworkEncryption,omitempty"`
+}
+
+type MongoDBSettings struct {
+	WireCompression []string `json:"wireCompression,omitempty"`
+}
+
+type SnowflakeSettings struct {
+	// RewriteAccountHost is always sent, so that setting it back to false
+	// is not mistaken for leaving it unset.
+	RewriteAccountHost bool `json:"rewriteAccountHost"`
+}
+
 type ReadSidecarListenerAPIResponse struct {
 	ListenerConfig *SidecarListener `json:"listenerConfig"`
 }
@@ -69,6 +132,10 @@ func (data ReadSidecarListenerAPIResponse) WriteToSchema(d *schema.ResourceData)
 		_ = d.Set(MySQLSettingsKey, data.ListenerConfig.MySQLSettingsAsInterface())
 		_ = d.Set(DynamoDbSettingsKey, data.ListenerConfig.DynamoDbSettingsAsInterface())
 		_ = d.Set(SQLServerSettingsKey, data.ListenerConfig.SQLServerSettingsAsInterface())
+		_ = d.Set(PostgreSQLSettingsKey, data.ListenerConfig.PostgreSQLSettingsAsInterface())
+		_ = d.Set(OracleSettingsKey, data.ListenerConfig.OracleSettingsAsInterface())
+		_ = d.Set(MongoDBSettingsKey, data.ListenerConfig.MongoDBSettingsAsInterface())
+		_ = d.Set(SnowflakeSettingsKey, data.ListenerConfig.SnowflakeSettingsAsInterface())
 	}
 	tflog.Debug(ctx, "End ReadSidecarListenerAPIResponse.WriteToSchema")
 	return nil
@@ -178,6 +245,82 @@ func (l *SidecarListener) SQLServerSettingsFromInterface(anInterface []interface
 	}
 }
 
+func (l *SidecarListener) PostgreSQLSettingsAsInterface() []interface{} {
+	if l.PostgreSQLSettings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		TLSModeKey:             l.PostgreSQLSettings.TLSMode,
+		WireProtocolVersionKey: l.PostgreSQLSettings.WireProtocolVersion,
+	}}
+}
+func (l *SidecarListener) PostgreSQLSettingsFromInterface(anInterface []interface{}) {
+	if len(anInterface) == 0 {
+		return
+	}
+	settings := anInterface[0].(map[string]interface{})
+	l.PostgreSQLSettings = &PostgreSQLSettings{
+		TLSMode:             settings[TLSModeKey].(string),
+		WireProtocolVersion: settings[WireProtocolVersionKey].(string),
+	}
+}
+func (l *SidecarListener) OracleSettingsAsInterface() []interface{} {
+	if l.OracleSettings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		ServiceNameMappingsKey:     l.OracleSettings.ServiceNameMappings,
+		NativeNetworkEncryptionKey: l.OracleSettings.NativeNetworkEncryption,
+	}}
+}
+func (l *SidecarListener) OracleSettingsFromInterface(anInterface []interface{}) {
+	if len(anInterface) == 0 {
+		return
+	}
+	settings := anInterface[0].(map[string]interface{})
+	mappings := make(map[string]string)
+	for serviceName, mapped := range settings[ServiceNameMappingsKey].(map[string]interface{}) {
+		mappings[serviceName] = mapped.(string)
+	}
+	l.OracleSettings = &OracleSettings{
+		ServiceNameMappings:     mappings,
+		NativeNetworkEncryption: settings[NativeNetworkEncryptionKey].(string),
+	}
+}
+func (l *SidecarListener) MongoDBSettingsAsInterface() []interface{} {
+	if l.MongoDBSettings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		WireCompressionKey: l.MongoDBSettings.WireCompression,
+	}}
+}
+func (l *SidecarListener) MongoDBSettingsFromInterface(anInterface []interface{}) {
+	if len(anInterface) == 0 {
+		return
+	}
+	settings := anInterface[0].(map[string]interface{})
+	l.MongoDBSettings = &MongoDBSettings{
+		WireCompression: utils.ConvertFromInterfaceList[string](settings[WireCompressionKey].([]interface{})),
+	}
+}
+func (l *SidecarListener) SnowflakeSettingsAsInterface() []interface{} {
+	if l.SnowflakeSettings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		RewriteAccountHostKey: l.SnowflakeSettings.RewriteAccountHost,
+	}}
+}
+func (l *SidecarListener) SnowflakeSettingsFromInterface(anInterface []interface{}) {
+	if len(anInterface) == 0 {
+		return
+	}
+	l.SnowflakeSettings = &SnowflakeSettings{
+		RewriteAccountHost: anInterface[0].(map[string]interface{})[RewriteAccountHostKey].(bool),
+	}
+}
+
 // SidecarListenerResource represents the payload of a create or update a listener request
 type SidecarListenerResource struct {
 	ListenerConfig SidecarListener `json:"listenerConfig"`
@@ -195,6 +338,10 @@ func (s *SidecarListenerResource) ReadFromSchema(d *schema.ResourceData) error {
 	s.ListenerConfig.S3SettingsFromInterface(d.Get(S3SettingsKey).(*schema.Set).List())
 	s.ListenerConfig.DynamoDbSettingsFromInterface(d.Get(DynamoDbSettingsKey).(*schema.Set).List())
 	s.ListenerConfig.SQLServerSettingsFromInterface(d.Get(SQLServerSettingsKey).(*schema.Set).List())
+	s.ListenerConfig.PostgreSQLSettingsFromInterface(d.Get(PostgreSQLSettingsKey).(*schema.Set).List())
+	s.ListenerConfig.OracleSettingsFromInterface(d.Get(OracleSettingsKey).(*schema.Set).List())
+	s.ListenerConfig.MongoDBSettingsFromInterface(d.Get(MongoDBSettingsKey).(*schema.Set).List())
+	s.ListenerConfig.SnowflakeSettingsFromInterface(d.Get(SnowflakeSettingsKey).(*schema.Set).List())
 
 	return nil
 }
@@ -216,14 +363,18 @@ func (data ReadDataSourceSidecarListenerAPIResponse) WriteToSchema(d *schema.Res
 		if (repoTypeFilter == "" || slices.Contains(listenerConfig.RepoTypes, repoTypeFilter)) &&
 			(portFilter == 0 || listenerConfig.NetworkAddress.Port == portFilter) {
 			listener := map[string]any{
-				utils.ListenerIDKey:  listenerConfig.ListenerId,
-				utils.SidecarIDKey:   d.Get(utils.SidecarIDKey).(string),
-				RepoTypesKey:         listenerConfig.RepoTypes,
-				NetworkAddressKey:    listenerConfig.NetworkAddressAsInterface(),
-				MySQLSettingsKey:     listenerConfig.MySQLSettingsAsInterface(),
-				S3SettingsKey:        listenerConfig.S3SettingsAsInterface(),
-				DynamoDbSettingsKey:  listenerConfig.DynamoDbSettingsAsInterface(),
-				SQLServerSettingsKey: listenerConfig.SQLServerSettingsAsInterface(),
+				utils.ListenerIDKey:   listenerConfig.ListenerId,
+				utils.SidecarIDKey:    d.Get(utils.SidecarIDKey).(string),
+				RepoTypesKey:          listenerConfig.RepoTypes,
+				NetworkAddressKey:     listenerConfig.NetworkAddressAsInterface(),
+				MySQLSettingsKey:      listenerConfig.MySQLSettingsAsInterface(),
+				S3SettingsKey:         listenerConfig.S3SettingsAsInterface(),
+				DynamoDbSettingsKey:   listenerConfig.DynamoDbSettingsAsInterface(),
+				SQLServerSettingsKey:  listenerConfig.SQLServerSettingsAsInterface(),
+				PostgreSQLSettingsKey: listenerConfig.PostgreSQLSettingsAsInterface(),
+				OracleSettingsKey:     listenerConfig.OracleSettingsAsInterface(),
+				MongoDBSettingsKey:    listenerConfig.MongoDBSettingsAsInterface(),
+				SnowflakeSettingsKey:  listenerConfig.SnowflakeSettingsAsInterface(),
 			}
 			tflog.Debug(ctx, fmt.Sprintf("listener: %q", listener))
 			listenersList = append(listenersList, listener)
@@ -243,3 +394,20 @@ func (data ReadDataSourceSidecarListenerAPIResponse) WriteToSchema(d *schema.Res
 
 	return nil
 }
+
+// ListSidecarListeners retrieves all the listeners of the sidecar.
+func ListSidecarListeners(ctx context.Context, c *client.Client, sidecarID string) ([]SidecarListener, error) {
+	tflog.Debug(ctx, "Init ListSidecarListeners")
+	url := fmt.Sprintf("https://%s/v1/sidecars/%s/listeners", c.ControlPlane, sidecarID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := ReadDataSourceSidecarListenerAPIResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshaled): %#v", resp))
+	tflog.Debug(ctx, "End ListSidecarListeners")
+	return resp.ListenerConfigs, nil
+}
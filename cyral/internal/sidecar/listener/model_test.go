@@ -0,0 +1,163 @@
+package listener
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSidecarListenerSettingsJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		listener SidecarListener
+		expected string
+	}{
+		{
+			desc: "snowflake rewrite disabled is sent",
+			listener: SidecarListener{
+				SnowflakeSettings: &SnowflakeSettings{RewriteAccountHost: false},
+			},
+			expected: `{"snowflakeSettings":{"rewriteAccountHost":false}}`,
+		},
+		{
+			desc: "snowflake rewrite enabled",
+			listener: SidecarListener{
+				SnowflakeSettings: &SnowflakeSettings{RewriteAccountHost: true},
+			},
+			expected: `{"snowflakeSettings":{"rewriteAccountHost":true}}`,
+		},
+		{
+			desc: "empty mongodb wire compression is omitted",
+			listener: SidecarListener{
+				MongoDBSettings: &MongoDBSettings{},
+			},
+			expected: `{"mongodbSettings":{}}`,
+		},
+		{
+			desc:     "no settings",
+			listener: SidecarListener{},
+			expected: `{}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			body, err := json.Marshal(testCase.listener)
+			require.NoError(t, err)
+
+			var actual map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &actual))
+			delete(actual, "id")
+			delete(actual, "repoTypes")
+			var expected map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.expected), &expected))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestNetworkAddressString(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		address  NetworkAddress
+		expected string
+	}{
+		{
+			desc:     "omitted host",
+			address:  NetworkAddress{Port: 5432},
+			expected: "0.0.0.0:5432",
+		},
+		{
+			desc:     "IPv4 host",
+			address:  NetworkAddress{Host: "10.0.0.1", Port: 5432},
+			expected: "10.0.0.1:5432",
+		},
+		{
+			desc:     "hostname",
+			address:  NetworkAddress{Host: "sidecar.local", Port: 3306},
+			expected: "sidecar.local:3306",
+		},
+		{
+			desc:     "IPv6 host",
+			address:  NetworkAddress{Host: "::1", Port: 5432},
+			expected: "[::1]:5432",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.address.String())
+		})
+	}
+}
+
+func TestNetworkAddressOverlaps(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		address        NetworkAddress
+		other          NetworkAddress
+		expectOverlaps bool
+	}{
+		{
+			desc:    "different ports",
+			address: NetworkAddress{Port: 5432},
+			other:   NetworkAddress{Port: 5433},
+		},
+		{
+			desc:           "both hosts omitted",
+			address:        NetworkAddress{Port: 5432},
+			other:          NetworkAddress{Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:           "omitted host and specific host",
+			address:        NetworkAddress{Port: 5432},
+			other:          NetworkAddress{Host: "10.0.0.1", Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:           "IPv4 wildcard and specific host",
+			address:        NetworkAddress{Host: "0.0.0.0", Port: 5432},
+			other:          NetworkAddress{Host: "10.0.0.1", Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:           "IPv6 wildcard and specific host",
+			address:        NetworkAddress{Host: "::", Port: 5432},
+			other:          NetworkAddress{Host: "10.0.0.1", Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:           "specific host and IPv6 wildcard",
+			address:        NetworkAddress{Host: "fd00::1", Port: 5432},
+			other:          NetworkAddress{Host: "::", Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:    "different specific hosts",
+			address: NetworkAddress{Host: "10.0.0.1", Port: 5432},
+			other:   NetworkAddress{Host: "10.0.0.2", Port: 5432},
+		},
+		{
+			desc:           "same hostname in different cases",
+			address:        NetworkAddress{Host: "Sidecar.local", Port: 5432},
+			other:          NetworkAddress{Host: "sidecar.local", Port: 5432},
+			expectOverlaps: true,
+		},
+		{
+			desc:           "same IPv6 host in different notations",
+			address:        NetworkAddress{Host: "fd00::1", Port: 5432},
+			other:          NetworkAddress{Host: "fd00:0:0::1", Port: 5432},
+			expectOverlaps: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expectOverlaps, testCase.address.Overlaps(testCase.other))
+			assert.Equal(t, testCase.expectOverlaps, testCase.other.Overlaps(testCase.address))
+		})
+	}
+}
@@ -3,8 +3,11 @@ package listener
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
 
 	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
@@ -43,11 +46,15 @@ func resourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages sidecar listeners." +
 			"\n~> **Warning** Multiple listeners can be associated to a single sidecar as long as " +
-			"`host` and `port` are unique. If `host` is omitted, then `port` must be unique.",
+			"`host` and `port` are unique. If `host` is omitted, then `port` must be unique. " +
+			"Conflicts with the existing listeners of the sidecar, and with the ports reported by " +
+			"[`cyral_sidecar_bound_ports`](../data-sources/sidecar_bound_ports.md), are reported at " +
+			"plan time. Conflicts between listeners created in the same apply are only reported by the API.",
 		CreateContext: resourceContextHandler.CreateContext(),
 		ReadContext:   resourceContextHandler.ReadContext(),
 		UpdateContext: resourceContextHandler.UpdateContext(),
 		DeleteContext: resourceContextHandler.DeleteContext(),
+		CustomizeDiff: resourceSidecarListenerCustomizeDiff,
 
 		Schema: getSidecarListenerSchema(),
 		Importer: &schema.ResourceImporter{
@@ -117,7 +124,7 @@ func getSidecarListenerSchema() map[string]*schema.Schema {
 			Optional:    true,
 			// Notice the MaxItems: 1 here. This ensures that the user can only specify one this block.
 			MaxItems:      1,
-			ConflictsWith: []string{S3SettingsKey, DynamoDbSettingsKey, SQLServerSettingsKey},
+			ConflictsWith: otherSettingsKeys(MySQLSettingsKey),
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					DbVersionKey: {
@@ -154,7 +161,7 @@ func getSidecarListenerSchema() map[string]*schema.Schema {
 			Optional:    true,
 			// Notice the MaxItems: 1 here. This ensures that the user can only specify one this block.
 			MaxItems:      1,
-			ConflictsWith: []string{MySQLSettingsKey, DynamoDbSettingsKey, SQLServerSettingsKey},
+			ConflictsWith: otherSettingsKeys(S3SettingsKey),
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					ProxyModeKey: {
@@ -184,7 +191,7 @@ func getSidecarListenerSchema() map[string]*schema.Schema {
 			Optional:    true,
 			// Notice the MaxItems: 1 here. This ensures that the user can only specify one this block.
 			MaxItems:      1,
-			ConflictsWith: []string{S3SettingsKey, MySQLSettingsKey, SQLServerSettingsKey},
+			ConflictsWith: otherSettingsKeys(DynamoDbSettingsKey),
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					ProxyModeKey: {
@@ -210,7 +217,7 @@ func getSidecarListenerSchema() map[string]*schema.Schema {
 			Optional:    true,
 			// Notice the MaxItems: 1 here. This ensures that the user can only specify one this block.
 			MaxItems:      1,
-			ConflictsWith: []string{S3SettingsKey, MySQLSettingsKey, DynamoDbSettingsKey},
+			ConflictsWith: otherSettingsKeys(SQLServerSettingsKey),
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					SQLServerVersionKey: {
@@ -232,5 +239,213 @@ func getSidecarListenerSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		PostgreSQLSettingsKey: {
+			Description: "PostgreSQL settings. Only allowed for listeners of types " +
+				settingsRepoTypesAsMarkdown(PostgreSQLSettingsKey) + ".",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: otherSettingsKeys(PostgreSQLSettingsKey),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					TLSModeKey: {
+						Description: "TLS mode required from the client applications connecting to the " +
+							"listener. If `" + string(PostgreSQLTLSPrefer) + "`, TLS is used when the client " +
+							"supports it. If omitted, the sidecar default is used. List of supported values:" +
+							utils.SupportedValuesAsMarkdown(PostgreSQLTLSModesAsString()),
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(PostgreSQLTLSModesAsString(), false),
+					},
+					WireProtocolVersionKey: {
+						Description: "Latest version of the PostgreSQL wire protocol that the listener " +
+							"negotiates with the client applications. If omitted, the sidecar default is used. " +
+							"List of supported values:" +
+							utils.SupportedValuesAsMarkdown(PostgreSQLWireProtocolVersionsAsString()),
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(PostgreSQLWireProtocolVersionsAsString(), false),
+					},
+				},
+			},
+		},
+		OracleSettingsKey: {
+			Description: "Oracle settings. Only allowed for listeners of type " +
+				settingsRepoTypesAsMarkdown(OracleSettingsKey) + ".",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: otherSettingsKeys(OracleSettingsKey),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ServiceNameMappingsKey: {
+						Description: "Map of service names used by the client applications to the service " +
+							"names of the database. Service names not in the map are forwarded unchanged.",
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					NativeNetworkEncryptionKey: {
+						Description: "Oracle native network encryption level of the listener, with the same " +
+							"semantics as `SQLNET.ENCRYPTION_SERVER`. If omitted, the sidecar default is used. " +
+							"List of supported values:" +
+							utils.SupportedValuesAsMarkdown(OracleNativeNetworkEncryptionsAsString()),
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(OracleNativeNetworkEncryptionsAsString(), false),
+					},
+				},
+			},
+		},
+		MongoDBSettingsKey: {
+			Description: "MongoDB settings. Only allowed for listeners of type " +
+				settingsRepoTypesAsMarkdown(MongoDBSettingsKey) + ".",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: otherSettingsKeys(MongoDBSettingsKey),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					WireCompressionKey: {
+						Description: "Wire compressors the listener accepts, in order of preference. If " +
+							"omitted or empty, the default compressors of the sidecar are used. List of " +
+							"supported values:" +
+							utils.SupportedValuesAsMarkdown(MongoDBCompressorsAsString()),
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(MongoDBCompressorsAsString(), false),
+						},
+					},
+				},
+			},
+		},
+		SnowflakeSettingsKey: {
+			Description: "Snowflake settings. Only allowed for listeners of type " +
+				settingsRepoTypesAsMarkdown(SnowflakeSettingsKey) + ".",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: otherSettingsKeys(SnowflakeSettingsKey),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					RewriteAccountHostKey: {
+						Description: "If `true`, the sidecar rewrites the Snowflake account host " +
+							"(`<account>.snowflakecomputing.com`) in the responses sent to the client " +
+							"applications to its own address, so that follow-up requests, such as result " +
+							"downloads, also go through the sidecar. Defaults to `false`.",
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// settingsRepoTypes are the repository types that each settings block,
+// validated at plan time, is allowed for.
+var settingsRepoTypes = map[string][]string{
+	PostgreSQLSettingsKey: {repository.PostgreSQL, repository.Redshift},
+	OracleSettingsKey:     {repository.Oracle},
+	MongoDBSettingsKey:    {repository.MongoDB},
+	SnowflakeSettingsKey:  {repository.Snowflake},
+}
+
+func settingsKeys() []string {
+	return []string{
+		MySQLSettingsKey,
+		S3SettingsKey,
+		DynamoDbSettingsKey,
+		SQLServerSettingsKey,
+		PostgreSQLSettingsKey,
+		OracleSettingsKey,
+		MongoDBSettingsKey,
+		SnowflakeSettingsKey,
+	}
+}
+
+// otherSettingsKeys returns the settings blocks that conflict with the given
+// one, since a listener has settings for a single repository type.
+func otherSettingsKeys(key string) []string {
+	var others []string
+	for _, settingsKey := range settingsKeys() {
+		if settingsKey != key {
+			others = append(others, settingsKey)
+		}
+	}
+	return others
+}
+
+func settingsRepoTypesAsMarkdown(key string) string {
+	repoTypes := make([]string, 0, len(settingsRepoTypes[key]))
+	for _, repoType := range settingsRepoTypes[key] {
+		repoTypes = append(repoTypes, "`"+repoType+"`")
+	}
+	return strings.Join(repoTypes, " and ")
+}
+
+// validateSettingsRepoTypes checks that the settings blocks are only set for
+// the repository types they apply to.
+func validateSettingsRepoTypes(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown(RepoTypesKey) {
+		return nil
+	}
+	repoTypes := utils.ConvertFromInterfaceList[string](d.Get(RepoTypesKey).([]interface{}))
+	for _, key := range settingsKeys() {
+		allowed, ok := settingsRepoTypes[key]
+		if !ok || d.Get(key).(*schema.Set).Len() == 0 {
+			continue
+		}
+		for _, repoType := range repoTypes {
+			if !slices.Contains(allowed, repoType) {
+				return fmt.Errorf("`%s` is only allowed for listeners of types %v, got repo type %q",
+					key, allowed, repoType)
+			}
+		}
+	}
+	return nil
+}
+
+// resourceSidecarListenerCustomizeDiff validates the settings blocks against
+// the repository types of the listener, and reports, at plan time, network
+// addresses that conflict with the other listeners of the sidecar, which the
+// API would otherwise reject at apply time. The ports bound to repositories
+// are the ports of the listeners, so they are also covered.
+func resourceSidecarListenerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateSettingsRepoTypes(d); err != nil {
+		return err
+	}
+	if d.Id() != "" && !d.HasChange(NetworkAddressKey) {
+		return nil
+	}
+	if !d.NewValueKnown(utils.SidecarIDKey) || !d.NewValueKnown(NetworkAddressKey) {
+		return nil
+	}
+	newListener := SidecarListener{}
+	newListener.NetworkAddressFromInterface(d.Get(NetworkAddressKey).(*schema.Set).List())
+	if newListener.NetworkAddress == nil || newListener.NetworkAddress.Port == 0 {
+		return nil
+	}
+	address := *newListener.NetworkAddress
+	sidecarID := d.Get(utils.SidecarIDKey).(string)
+	listenerID := d.Get(utils.ListenerIDKey).(string)
+
+	listeners, err := ListSidecarListeners(ctx, m.(*client.Client), sidecarID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the listeners of sidecar %q: %w", sidecarID, err)
+	}
+	for _, other := range listeners {
+		if other.NetworkAddress == nil || other.ListenerId == listenerID {
+			continue
+		}
+		if address.Overlaps(*other.NetworkAddress) {
+			return fmt.Errorf("network address %s conflicts with listener %q (repo types %v) of sidecar %q, "+
+				"which listens on %s", address, other.ListenerId, other.RepoTypes, sidecarID, other.NetworkAddress)
+		}
 	}
+	return nil
 }
@@ -38,6 +38,66 @@ func TestSidecarListenerResource(t *testing.T) {
 	)
 }
 
+func TestSidecarListenerResource_PortConflicts(t *testing.T) {
+	existing := listen.SidecarListener{
+		RepoTypes: []string{"postgresql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 7000,
+		},
+	}
+	samePortOtherHost := listen.SidecarListener{
+		RepoTypes: []string{"mysql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 7000,
+			Host: "10.0.0.1",
+		},
+	}
+	otherPort := listen.SidecarListener{
+		RepoTypes: []string{"mysql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 7001,
+			Host: "10.0.0.1",
+		},
+	}
+	sidecarConfig := utils.FormatBasicSidecarIntoConfig(
+		utils.BasicSidecarResName,
+		utils.AccTestName(sidecarListenerTestSidecarResourceName, "sidecar-port-conflicts"),
+		"docker", "",
+	)
+	existingConfig := sidecarConfig + SetupSidecarListenerConfig("existing", existing)
+
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: existingConfig,
+					Check:  setupSidecarListenerCheck("existing", existing),
+				},
+				{
+					// The existing listener listens on all network
+					// interfaces, so any host conflicts with it.
+					Config:      existingConfig + SetupSidecarListenerConfig("conflicting", samePortOtherHost),
+					ExpectError: regexp.MustCompile(`network address 10.0.0.1:7000 conflicts with listener`),
+				},
+				{
+					Config: existingConfig + SetupSidecarListenerConfig("conflicting", otherPort),
+					Check: resource.ComposeTestCheckFunc(
+						setupSidecarListenerCheck("existing", existing),
+						setupSidecarListenerCheck("conflicting", otherPort),
+					),
+				},
+				{
+					// Moving the listener to the port of the existing one is
+					// also reported at plan time.
+					Config:      existingConfig + SetupSidecarListenerConfig("conflicting", samePortOtherHost),
+					ExpectError: regexp.MustCompile(`network address 10.0.0.1:7000 conflicts with listener`),
+				},
+			},
+		},
+	)
+}
+
 func updateTest() []resource.TestStep {
 	// Start with a bare bones mySQL sidecar listener
 	onlyRequiredFields := listen.SidecarListener{
@@ -151,7 +211,108 @@ func settingsTest() []resource.TestStep {
 		},
 	}
 
+	postgresqlSettings := listen.SidecarListener{
+		RepoTypes: []string{"postgresql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8005,
+		},
+		PostgreSQLSettings: &listen.PostgreSQLSettings{
+			TLSMode:             "require",
+			WireProtocolVersion: "3.0",
+		},
+	}
+	oracleSettings := listen.SidecarListener{
+		RepoTypes: []string{"oracle"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8006,
+		},
+		OracleSettings: &listen.OracleSettings{
+			ServiceNameMappings: map[string]string{
+				"SALES": "SALESPDB",
+			},
+			NativeNetworkEncryption: "REQUIRED",
+		},
+	}
+	mongodbSettings := listen.SidecarListener{
+		RepoTypes: []string{"mongodb"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8007,
+		},
+		MongoDBSettings: &listen.MongoDBSettings{
+			WireCompression: []string{"zstd", "snappy"},
+		},
+	}
+	snowflakeSettings := listen.SidecarListener{
+		RepoTypes: []string{"snowflake"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8008,
+		},
+		SnowflakeSettings: &listen.SnowflakeSettings{
+			RewriteAccountHost: true,
+		},
+	}
+	// Settings of a repo type other than the one of the listener.
+	mismatchedSettings := listen.SidecarListener{
+		RepoTypes: []string{"mysql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8009,
+		},
+		PostgreSQLSettings: &listen.PostgreSQLSettings{
+			TLSMode: "require",
+		},
+	}
+	// Unsupported values.
+	invalidTLSMode := listen.SidecarListener{
+		RepoTypes: []string{"postgresql"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8009,
+		},
+		PostgreSQLSettings: &listen.PostgreSQLSettings{
+			TLSMode: "verify-full",
+		},
+	}
+	invalidCompressor := listen.SidecarListener{
+		RepoTypes: []string{"mongodb"},
+		NetworkAddress: &listen.NetworkAddress{
+			Port: 8009,
+		},
+		MongoDBSettings: &listen.MongoDBSettings{
+			WireCompression: []string{"gzip"},
+		},
+	}
+
 	return []resource.TestStep{
+		{
+			Config: sidecarListenerSidecarConfig() +
+				SetupSidecarListenerConfig("mismatched_settings", mismatchedSettings),
+			ExpectError: regexp.MustCompile("`postgresql_settings` is only allowed for listeners of types"),
+		},
+		{
+			Config: sidecarListenerSidecarConfig() +
+				SetupSidecarListenerConfig("invalid_tls_mode", invalidTLSMode),
+			ExpectError: regexp.MustCompile(`expected postgresql_settings.0.tls_mode to be one of`),
+		},
+		{
+			Config: sidecarListenerSidecarConfig() +
+				SetupSidecarListenerConfig("invalid_compressor", invalidCompressor),
+			ExpectError: regexp.MustCompile(`expected mongodb_settings.0.wire_compression.0 to be one of`),
+		},
+		setupSidecarListenerTestStep(
+			"postgresql_settings",
+			postgresqlSettings,
+		),
+		setupSidecarListenerTestStep(
+			"oracle_settings",
+			oracleSettings,
+		),
+		setupSidecarListenerTestStep(
+			"mongodb_settings",
+			mongodbSettings,
+		),
+		setupSidecarListenerTestStep(
+			"snowflake_settings",
+			snowflakeSettings,
+		),
 		setupSidecarListenerTestStep(
 			"mySQL_no_charset",
 			mySQLNoCharSet,
@@ -239,6 +400,10 @@ func settingsConflictsTest() []resource.TestStep {
 		"s3",
 		"dynamodb",
 		"sqlserver",
+		"postgresql",
+		"oracle",
+		"mongodb",
+		"snowflake",
 	}
 	var testSteps []resource.TestStep
 	// Generate test steps for every pair of conflicting repo types
@@ -288,6 +453,22 @@ func appendSetting(l *listen.SidecarListener, repoType string) {
 		l.SQLServerSettings = &listen.SQLServerSettings{
 			Version: "16.0.1000",
 		}
+	case "postgresql":
+		l.PostgreSQLSettings = &listen.PostgreSQLSettings{
+			TLSMode: "require",
+		}
+	case "oracle":
+		l.OracleSettings = &listen.OracleSettings{
+			NativeNetworkEncryption: "REQUIRED",
+		}
+	case "mongodb":
+		l.MongoDBSettings = &listen.MongoDBSettings{
+			WireCompression: []string{"zstd"},
+		}
+	case "snowflake":
+		l.SnowflakeSettings = &listen.SnowflakeSettings{
+			RewriteAccountHost: true,
+		}
 	}
 }
 
@@ -411,6 +592,98 @@ func setupSidecarListenerCheck(resourceName string, listener listen.SidecarListe
 		)
 	}
 
+	if listener.PostgreSQLSettings != nil {
+		checkFuncs = append(
+			checkFuncs, []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.#", listen.PostgreSQLSettingsKey),
+					"1",
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s", listen.PostgreSQLSettingsKey, listen.TLSModeKey),
+					listener.PostgreSQLSettings.TLSMode,
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s", listen.PostgreSQLSettingsKey, listen.WireProtocolVersionKey),
+					listener.PostgreSQLSettings.WireProtocolVersion,
+				),
+			}...,
+		)
+	}
+
+	if listener.OracleSettings != nil {
+		checkFuncs = append(
+			checkFuncs, []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.#", listen.OracleSettingsKey),
+					"1",
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s.%%", listen.OracleSettingsKey, listen.ServiceNameMappingsKey),
+					strconv.Itoa(len(listener.OracleSettings.ServiceNameMappings)),
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s", listen.OracleSettingsKey, listen.NativeNetworkEncryptionKey),
+					listener.OracleSettings.NativeNetworkEncryption,
+				),
+			}...,
+		)
+		for serviceName, mapped := range listener.OracleSettings.ServiceNameMappings {
+			checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr(
+				resFullName,
+				fmt.Sprintf("%s.0.%s.%s", listen.OracleSettingsKey, listen.ServiceNameMappingsKey, serviceName),
+				mapped,
+			))
+		}
+	}
+
+	if listener.MongoDBSettings != nil {
+		checkFuncs = append(
+			checkFuncs, []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.#", listen.MongoDBSettingsKey),
+					"1",
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s.#", listen.MongoDBSettingsKey, listen.WireCompressionKey),
+					strconv.Itoa(len(listener.MongoDBSettings.WireCompression)),
+				),
+			}...,
+		)
+		for i, compressor := range listener.MongoDBSettings.WireCompression {
+			checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr(
+				resFullName,
+				fmt.Sprintf("%s.0.%s.%d", listen.MongoDBSettingsKey, listen.WireCompressionKey, i),
+				compressor,
+			))
+		}
+	}
+
+	if listener.SnowflakeSettings != nil {
+		checkFuncs = append(
+			checkFuncs, []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.#", listen.SnowflakeSettingsKey),
+					"1",
+				),
+				resource.TestCheckResourceAttr(
+					resFullName,
+					fmt.Sprintf("%s.0.%s", listen.SnowflakeSettingsKey, listen.RewriteAccountHostKey),
+					strconv.FormatBool(listener.SnowflakeSettings.RewriteAccountHost),
+				),
+			}...,
+		)
+	}
+
 	return resource.ComposeTestCheckFunc(checkFuncs...)
 }
 
@@ -479,6 +752,48 @@ func SetupSidecarListenerConfig(resourceName string, listener listen.SidecarList
 		)
 	}
 
+	if listener.PostgreSQLSettings != nil {
+		settings += fmt.Sprintf(
+			`
+		postgresql_settings {
+			tls_mode = %s
+			wire_protocol_version = %s
+		}`, stringOrNull(listener.PostgreSQLSettings.TLSMode),
+			stringOrNull(listener.PostgreSQLSettings.WireProtocolVersion),
+		)
+	}
+	if listener.OracleSettings != nil {
+		var mappings string
+		for serviceName, mapped := range listener.OracleSettings.ServiceNameMappings {
+			mappings += fmt.Sprintf("%q = %q\n", serviceName, mapped)
+		}
+		settings += fmt.Sprintf(
+			`
+		oracle_settings {
+			service_name_mappings = {
+				%s
+			}
+			native_network_encryption = %s
+		}`, mappings, stringOrNull(listener.OracleSettings.NativeNetworkEncryption),
+		)
+	}
+	if listener.MongoDBSettings != nil {
+		settings += fmt.Sprintf(
+			`
+		mongodb_settings {
+			wire_compression = %s
+		}`, utils.ListToStr(listener.MongoDBSettings.WireCompression),
+		)
+	}
+	if listener.SnowflakeSettings != nil {
+		settings += fmt.Sprintf(
+			`
+		snowflake_settings {
+			rewrite_account_host = %s
+		}`, strconv.FormatBool(listener.SnowflakeSettings.RewriteAccountHost),
+		)
+	}
+
 	config += fmt.Sprintf(
 		`
 	resource "cyral_sidecar_listener" "%s" {
@@ -490,3 +805,10 @@ func SetupSidecarListenerConfig(resourceName string, listener listen.SidecarList
 	)
 	return config
 }
+
+func stringOrNull(value string) string {
+	if value == "" {
+		return "null"
+	}
+	return fmt.Sprintf("%q", value)
+}
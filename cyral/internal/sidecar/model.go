@@ -2,10 +2,15 @@ package sidecar
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 type CreateSidecarResponse struct {
@@ -22,10 +27,27 @@ type SidecarData struct {
 	CertificateBundleSecrets CertificateBundleSecrets `json:"certificateBundleSecrets,omitempty"`
 }
 
+// GetSidecar retrieves the sidecar with the given ID.
+func GetSidecar(ctx context.Context, c *client.Client, sidecarID string) (*SidecarData, error) {
+	tflog.Debug(ctx, "Init GetSidecar")
+	url := fmt.Sprintf("https://%s/v1/sidecars/%s", c.ControlPlane, sidecarID)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	sidecarData := &SidecarData{}
+	if err := json.Unmarshal(body, sidecarData); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshaled): %#v", sidecarData))
+	tflog.Debug(ctx, "End GetSidecar")
+	return sidecarData, nil
+}
+
 func (sd *SidecarData) BypassMode() string {
 	if sd.ServicesConfig != nil {
-		if dispConfig, ok := sd.ServicesConfig["dispatcher"]; ok {
-			if bypass_mode, ok := dispConfig["bypass"]; ok {
+		if dispConfig, ok := sd.ServicesConfig[dispatcherService]; ok {
+			if bypass_mode, ok := dispConfig[bypassSetting]; ok {
 				return bypass_mode
 			}
 		}
@@ -62,9 +84,17 @@ func (r *SidecarData) WriteToSchema(d *schema.ResourceData) error {
 			return fmt.Errorf("error setting 'bypass_mode' field: %w", err)
 		}
 	}
+	managedServices := managedServiceSettings(d.Get(ServicesKey).(*schema.Set))
+	if err := d.Set(ServicesKey, flattenServices(r.ServicesConfig, managedServices)); err != nil {
+		return fmt.Errorf("error setting '%s' field: %w", ServicesKey, err)
+	}
 
-	if err := d.Set("certificate_bundle_secrets", flattenCertificateBundleSecrets(r.CertificateBundleSecrets)); err != nil {
-		return fmt.Errorf("error setting 'certificate_bundle_secrets' field: %w", err)
+	if err := d.Set(CertificateBundleSecretsKey, flattenCertificateBundleSecrets(r.CertificateBundleSecrets)); err != nil {
+		return fmt.Errorf("error setting '%s' field: %w", CertificateBundleSecretsKey, err)
+	}
+	selfSigned := flattenSelfSignedCertificateBundle(r.CertificateBundleSecrets)
+	if err := d.Set(SelfSignedCertificateBundleKey, selfSigned); err != nil {
+		return fmt.Errorf("error setting '%s' field: %w", SelfSignedCertificateBundleKey, err)
 	}
 	return nil
 }
@@ -92,11 +122,11 @@ func (r *SidecarData) ReadFromSchema(d *schema.ResourceData) error {
 		DiagnosticLogIntegrationID: d.Get("diagnostic_log_integration_id").(string),
 		VaultIntegrationID:         d.Get("vault_integration_id").(string),
 	}
-	r.ServicesConfig = SidecarServicesConfig{
-		"dispatcher": map[string]string{
-			"bypass": d.Get("bypass_mode").(string),
-		},
+	r.ServicesConfig = servicesConfigFromSet(d.Get(ServicesKey).(*schema.Set))
+	if _, ok := r.ServicesConfig[dispatcherService]; !ok {
+		r.ServicesConfig[dispatcherService] = make(map[string]string)
 	}
+	r.ServicesConfig[dispatcherService][bypassSetting] = d.Get(BypassModeKey).(string)
 	r.UserEndpoint = d.Get("user_endpoint").(string)
 	r.CertificateBundleSecrets = getCertificateBundleSecret(d)
 
@@ -128,20 +158,19 @@ func flattenCertificateBundleSecrets(cbs CertificateBundleSecrets) []interface{}
 		cb := make(map[string]interface{})
 
 		for key, val := range cbs {
-			// Ignore self-signed certificates
-			if key != "sidecar-generated-selfsigned" {
-				contentCB := make([]interface{}, 1)
-
-				tflog.Debug(ctx, fmt.Sprintf("key: %v", key))
-				tflog.Debug(ctx, fmt.Sprintf("val: %v", val))
-
-				contentCBMap := make(map[string]interface{})
-				contentCBMap["secret_id"] = val.SecretId
-				contentCBMap["engine"] = val.Engine
-				contentCBMap["type"] = val.Type
-
-				contentCB[0] = contentCBMap
-				cb[key] = contentCB
+			// The self-signed bundle is managed by the control plane and
+			// exposed separately.
+			if key == selfSignedBundleName || val == nil || val.Type == "" {
+				continue
+			}
+			tflog.Debug(ctx, fmt.Sprintf("key: %v", key))
+			tflog.Debug(ctx, fmt.Sprintf("val: %v", val))
+			cb[key] = []interface{}{
+				map[string]interface{}{
+					EngineKey:     val.Engine,
+					SecretIDKey:   val.SecretId,
+					utils.TypeKey: val.Type,
+				},
 			}
 		}
 
@@ -158,38 +187,32 @@ func flattenCertificateBundleSecrets(cbs CertificateBundleSecrets) []interface{}
 func getCertificateBundleSecret(d *schema.ResourceData) CertificateBundleSecrets {
 	ctx := context.Background()
 	tflog.Debug(ctx, "Init getCertificateBundleSecret")
-	rdCBS := d.Get("certificate_bundle_secrets").(*schema.Set).List()
+	rdCBS := d.Get(CertificateBundleSecretsKey).(*schema.Set).List()
 	ret := make(CertificateBundleSecrets)
 
-	if len(rdCBS) > 0 {
-		cbsMap := rdCBS[0].(map[string]interface{})
-		for k, v := range cbsMap {
+	if len(rdCBS) > 0 && rdCBS[0] != nil {
+		for k, v := range rdCBS[0].(map[string]interface{}) {
 			vList := v.(*schema.Set).List()
-			// 1. k = "sidecar" or other direct internal elements of certificate_bundle_secrets
-			// 2. Also one element on this list due to MaxItems...
-			// 3. Ignore self signed certificates
-			if len(vList) > 0 && k != "sidecar-generated-selfsigned" {
+			// MaxItems is 1, so there is at most one element in the list.
+			if len(vList) > 0 && vList[0] != nil {
 				vMap := vList[0].(map[string]interface{})
 				engine := ""
-				if val, ok := vMap["engine"]; val != nil && ok {
+				if val, ok := vMap[EngineKey]; val != nil && ok {
 					engine = val.(string)
 				}
-				cbsType := vMap["type"].(string)
-				secretId := vMap["secret_id"].(string)
-				cbs := CertificateBundleSecret{
-					SecretId: secretId,
+				ret[k] = &CertificateBundleSecret{
+					SecretId: vMap[SecretIDKey].(string),
 					Engine:   engine,
-					Type:     cbsType,
+					Type:     vMap[utils.TypeKey].(string),
 				}
-				ret[k] = &cbs
 			}
 		}
 	}
 
 	// If the occurrence of `sidecar` does not exist, set it to an empty certificate bundle
 	// so that the API can remove the `sidecar` key from the persisted certificate bundle map.
-	if _, ok := ret["sidecar"]; !ok {
-		ret["sidecar"] = &CertificateBundleSecret{}
+	if _, ok := ret[CertificateBundleSidecarKey]; !ok {
+		ret[CertificateBundleSidecarKey] = &CertificateBundleSecret{}
 	}
 
 	tflog.Debug(ctx, "end getCertificateBundleSecret")
@@ -1,9 +1,12 @@
 package sidecar
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -11,12 +14,18 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/operationtype"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/core/types/resourcetype"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
 )
 
 var urlFactory = func(d *schema.ResourceData, c *client.Client) string {
 	return fmt.Sprintf("https://%s/v1/sidecars/%s", c.ControlPlane, d.Id())
 }
 
+func sidecarIDFromResource(d *schema.ResourceData) string {
+	return d.Id()
+}
+
 var readConfig = core.ResourceOperationConfig{
 	ResourceName:        resourceName,
 	ResourceType:        resourcetype.Resource,
@@ -31,6 +40,34 @@ var readConfig = core.ResourceOperationConfig{
 	},
 }
 
+// resourceSidecarUpdate updates the sidecar, keeping the service settings
+// that are not managed through the `services` block, as the API replaces
+// the whole services configuration.
+func resourceSidecarUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init resourceSidecarUpdate")
+	c := m.(*client.Client)
+
+	current, err := GetSidecar(ctx, c, d.Id())
+	if err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to read %s", resourceName), err.Error())
+	}
+	sidecarData := &SidecarData{}
+	if err := sidecarData.ReadFromSchema(d); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName), err.Error())
+	}
+	oldServices, _ := d.GetChange(ServicesKey)
+	sidecarData.ServicesConfig = mergeServicesConfig(
+		current.ServicesConfig, sidecarData.ServicesConfig,
+		managedServiceSettings(oldServices.(*schema.Set)),
+	)
+	if _, err := c.DoRequest(ctx, urlFactory(d, c), http.MethodPut, sidecarData); err != nil {
+		return utils.CreateError(fmt.Sprintf("Unable to update %s", resourceName), err.Error())
+	}
+
+	tflog.Debug(ctx, "End resourceSidecarUpdate")
+	return core.ReadResource(readConfig)(ctx, d, m)
+}
+
 func resourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages [sidecars](https://cyral.com/docs/sidecars/manage).",
@@ -48,19 +85,8 @@ func resourceSchema() *schema.Resource {
 			},
 			readConfig,
 		),
-		ReadContext: core.ReadResource(readConfig),
-		UpdateContext: core.UpdateResource(
-			core.ResourceOperationConfig{
-				ResourceName:        resourceName,
-				ResourceType:        resourcetype.Resource,
-				Type:                operationtype.Update,
-				HttpMethod:          http.MethodPut,
-				URLFactory:          urlFactory,
-				SchemaReaderFactory: func() core.SchemaReader { return &SidecarData{} },
-				SchemaWriterFactory: func(_ *schema.ResourceData) core.SchemaWriter { return &SidecarData{} },
-			},
-			readConfig,
-		),
+		ReadContext:   core.ReadResource(readConfig),
+		UpdateContext: health.WithWaitForHealthy(sidecarIDFromResource, resourceSidecarUpdate),
 		DeleteContext: core.DeleteResource(
 			core.ResourceOperationConfig{
 				ResourceName: resourceName,
@@ -87,16 +113,10 @@ func resourceSchema() *schema.Resource {
 				Required:    true,
 			},
 			"deployment_method": {
-				Description: "Deployment method that will be used by this sidecar (valid values: `docker`, `cft-ec2`, `terraform`, `helm3`, `automated`, `custom`, `terraformGKE`, `linux`, and `singleContainer`).",
-				Type:        schema.TypeString,
-				Required:    true,
-				ValidateFunc: validation.StringInSlice(
-					[]string{
-						"docker", "cft-ec2", "terraform", "helm3",
-						"automated", "custom", "terraformGKE", "singleContainer",
-						"linux",
-					}, false,
-				),
+				Description:  "Deployment method that will be used by this sidecar (valid values: `docker`, `cft-ec2`, `terraform`, `helm3`, `automated`, `custom`, `terraformGKE`, `linux`, and `singleContainer`).",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(deploymentMethods(), false),
 			},
 			"log_integration_id": {
 				Description:   "ID of the log integration mapped to this sidecar, used for Cyral activity logs.",
@@ -134,7 +154,7 @@ func resourceSchema() *schema.Resource {
 				Optional:    true,
 			},
 			"bypass_mode": {
-				Description: "This argument lets you specify how to handle the connection in the event of an error in the sidecar during a user’s session. Valid modes are: `always`, `failover` or `never`. Defaults to `failover`. If `always` is specified, the sidecar will run in [passthrough mode](https://cyral.com/docs/sidecars/manage#passthrough-mode). If `failover` is specified, the sidecar will run in [resiliency mode](https://cyral.com/docs/sidecars/manage#resilient-mode-of-sidecar-operation). If `never` is specified and there is an error in the sidecar, connections to bound repositories will fail.",
+				Description: "This argument lets you specify how to handle the connection in the event of an error in the sidecar during a user’s session. Valid modes are: `always`, `failover` or `never`. Defaults to `failover`. If `always` is specified, the sidecar will run in [passthrough mode](https://cyral.com/docs/sidecars/manage#passthrough-mode). If `failover` is specified, the sidecar will run in [resiliency mode](https://cyral.com/docs/sidecars/manage#resilient-mode-of-sidecar-operation). If `never` is specified and there is an error in the sidecar, connections to bound repositories will fail. This is the `bypass` setting of the `dispatcher` service, which cannot be set through [`services`](#services).",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "failover",
@@ -146,7 +166,11 @@ func resourceSchema() *schema.Resource {
 					}, false,
 				),
 			},
-			"certificate_bundle_secrets": {
+			ServicesKey: servicesSchema(),
+			// Sidecars are not deployed yet when they are created, so only
+			// updates wait for them to become healthy.
+			health.WaitForHealthyKey: health.WaitForHealthySchema("update"),
+			CertificateBundleSecretsKey: {
 				Deprecated: "Since sidecar v4.7 the certificate is managed at deployment level. Refer" +
 					" to [our public docs](https://cyral.com/docs/sidecars/deployment/certificates)" +
 					" for more information.",
@@ -157,42 +181,26 @@ func resourceSchema() *schema.Resource {
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"sidecar": {
+						CertificateBundleSidecarKey: {
 							Description: "Certificate Bundle Secret for sidecar.",
 							Type:        schema.TypeSet,
 							MaxItems:    1,
 							Required:    true,
 							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"engine": {
-										Description: "Engine is the name of the engine used with the given secrets" +
-											" manager type, when applicable.",
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"secret_id": {
-										Description: "Secret ID is the identifier or location for the secret that" +
-											" holds the certificate bundle.",
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"type": {
-										Description: "Type identifies the secret manager used to store the secret. Valid values are: `aws` and `k8s`.",
-										Type:        schema.TypeString,
-										Required:    true,
-										ValidateFunc: validation.StringInSlice(
-											[]string{
-												"aws",
-												"k8s",
-											}, false,
-										),
-									},
-								},
+								Schema: certificateBundleSecretSchema(),
 							},
 						},
 					},
 				},
 			},
+			SelfSignedCertificateBundleKey: selfSignedCertificateBundleSchema(),
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateCertificateBundleSecretIDs,
+		},
+		CustomizeDiff: validateServices,
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(health.DefaultWaitTimeout),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
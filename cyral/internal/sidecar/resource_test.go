@@ -2,6 +2,7 @@ package sidecar_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -191,6 +192,197 @@ func TestAccSidecarResource(t *testing.T) {
 	)
 }
 
+func TestAccSidecarResource_WaitForHealthy(t *testing.T) {
+	sidecarName := utils.AccTestName(utils.SidecarResourceName, "waitForHealthy")
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: formatSidecarWaitForHealthyConfig(sidecarName, "docker", 0),
+					ExpectError: regexp.MustCompile(
+						`expected wait_for_healthy.0.min_instances to be at least \(1\)`,
+					),
+				},
+				{
+					// Creating the sidecar does not wait for it to become
+					// healthy, since it is not deployed yet.
+					Config: formatSidecarWaitForHealthyConfig(sidecarName, "docker", 1),
+					Check: resource.TestCheckResourceAttr(
+						"cyral_sidecar.test_sidecar", "wait_for_healthy.0.min_instances", "1",
+					),
+				},
+				{
+					// No instances are deployed for the sidecar, so it never
+					// becomes healthy.
+					Config: formatSidecarWaitForHealthyConfig(sidecarName, "helm3", 1),
+					ExpectError: regexp.MustCompile(
+						`(?s)did not become healthy within 1s.*No instances are registered`,
+					),
+				},
+			},
+		},
+	)
+}
+
+func formatSidecarWaitForHealthyConfig(sidecarName, deploymentMethod string, minInstances int) string {
+	return fmt.Sprintf(
+		`
+	resource "cyral_sidecar" "test_sidecar" {
+		name = "%s"
+		deployment_method = "%s"
+		wait_for_healthy {
+			min_instances = %d
+		}
+		timeouts {
+			update = "1s"
+		}
+	}`, sidecarName, deploymentMethod, minInstances,
+	)
+}
+
+func TestAccSidecarResource_CertificateBundleSecrets(t *testing.T) {
+	sidecarName := utils.AccTestName(utils.SidecarResourceName, "certificateBundle")
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: formatSidecarCertificateBundleConfig(sidecarName, `
+					type = "vault"
+					secret_id = "sidecars/tls-bundle"`),
+					ExpectError: regexp.MustCompile(`expected type to be one of`),
+				},
+				{
+					Config: formatSidecarCertificateBundleConfig(sidecarName, `
+					type = "aws"
+					secret_id = "arn:aws:secretsmanager:us-east-1:123456789012:secret:tls-bundle"`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs(
+							"cyral_sidecar.test_sidecar", "certificate_bundle_secrets.*.sidecar.*",
+							map[string]string{
+								"type":      "aws",
+								"secret_id": "arn:aws:secretsmanager:us-east-1:123456789012:secret:tls-bundle",
+							},
+						),
+					),
+				},
+				{
+					Config: formatSidecarCertificateBundleConfig(sidecarName, `
+					type = "k8s"
+					secret_id = "tls-bundle"`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs(
+							"cyral_sidecar.test_sidecar", "certificate_bundle_secrets.*.sidecar.*",
+							map[string]string{
+								"type":      "k8s",
+								"secret_id": "tls-bundle",
+							},
+						),
+					),
+				},
+				{
+					ImportState:       true,
+					ImportStateVerify: true,
+					ResourceName:      "cyral_sidecar.test_sidecar",
+				},
+			},
+		},
+	)
+}
+
+func formatSidecarCertificateBundleConfig(sidecarName, sidecarBundle string) string {
+	return fmt.Sprintf(
+		`
+	resource "cyral_sidecar" "test_sidecar" {
+		name = "%s"
+		deployment_method = "docker"
+		certificate_bundle_secrets {
+			sidecar {%s
+			}
+		}
+	}`, sidecarName, sidecarBundle,
+	)
+}
+
+func TestAccSidecarResource_Services(t *testing.T) {
+	sidecarName := utils.AccTestName(utils.SidecarResourceName, "services")
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProviderFactories: provider.ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: formatSidecarServicesConfig(sidecarName, "failover", `
+					services {
+						name = "dispatcher"
+						settings = {
+							bypass = "always"
+						}
+					}`),
+					ExpectError: regexp.MustCompile("use `bypass_mode` instead"),
+				},
+				{
+					Config: formatSidecarServicesConfig(sidecarName, "failover", `
+					services {
+						name = "pg-wire"
+						settings = {
+							maxConnections = "100"
+						}
+					}
+					services {
+						name = "pg-wire"
+					}`),
+					ExpectError: regexp.MustCompile(`service "pg-wire" is configured more than once`),
+				},
+				{
+					Config: formatSidecarServicesConfig(sidecarName, "never", `
+					services {
+						name = "pg-wire"
+						settings = {
+							maxConnections = "100"
+						}
+					}`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("cyral_sidecar.test_sidecar", "bypass_mode", "never"),
+						resource.TestCheckResourceAttr("cyral_sidecar.test_sidecar", "services.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							"cyral_sidecar.test_sidecar", "services.*",
+							map[string]string{
+								"name":                    "pg-wire",
+								"settings.maxConnections": "100",
+							},
+						),
+					),
+				},
+				{
+					Config: formatSidecarServicesConfig(sidecarName, "always", ""),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("cyral_sidecar.test_sidecar", "bypass_mode", "always"),
+						resource.TestCheckResourceAttr("cyral_sidecar.test_sidecar", "services.#", "0"),
+					),
+				},
+				{
+					ImportState:       true,
+					ImportStateVerify: true,
+					ResourceName:      "cyral_sidecar.test_sidecar",
+				},
+			},
+		},
+	)
+}
+
+func formatSidecarServicesConfig(sidecarName, bypassMode, services string) string {
+	return fmt.Sprintf(
+		`
+	resource "cyral_sidecar" "test_sidecar" {
+		name = "%s"
+		deployment_method = "docker"
+		bypass_mode = "%s"
+		%s
+	}`, sidecarName, bypassMode, services,
+	)
+}
+
 func setupSidecarTest(sidecarData sidecar.SidecarData) (string, resource.TestCheckFunc) {
 	configuration := formatSidecarDataIntoConfig(sidecarData)
 
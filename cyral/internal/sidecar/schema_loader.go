@@ -18,6 +18,11 @@ func (p *packageSchema) Schemas() []*core.SchemaDescriptor {
 			Type:   core.ResourceSchemaType,
 			Schema: resourceSchema,
 		},
+		{
+			Name:   dataSourceSidecarsName,
+			Type:   core.DataSourceSchemaType,
+			Schema: dataSourceSchema,
+		},
 	}
 }
 
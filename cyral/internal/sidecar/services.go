@@ -0,0 +1,149 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func servicesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Per-service configuration of the sidecar. Only the services and settings listed here" +
+			" are managed, the other settings of the sidecar services are left unchanged. The `" + bypassSetting +
+			"` setting of the `" + dispatcherService + "` service is managed through [`" + BypassModeKey +
+			"`](#" + BypassModeKey + ") and cannot be set here.",
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				utils.NameKey: {
+					Description:  "Name of the service (ex: `" + dispatcherService + "`).",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				SettingsKey: {
+					Description: "Settings of the service, passed as-is to the sidecar.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// servicesConfigFromSet builds the API representation of the `services`
+// block. The bypass mode of the dispatcher is not included.
+func servicesConfigFromSet(services *schema.Set) SidecarServicesConfig {
+	config := make(SidecarServicesConfig)
+	for _, serviceIface := range services.List() {
+		service := serviceIface.(map[string]interface{})
+		settings := make(map[string]string)
+		for key, value := range service[SettingsKey].(map[string]interface{}) {
+			settings[key] = value.(string)
+		}
+		config[service[utils.NameKey].(string)] = settings
+	}
+	return config
+}
+
+// managedServiceSettings returns, for each service of the `services`
+// block, the API settings that are set through it. Only these settings are
+// managed by the provider, the other settings of the services are left as
+// they are in the control plane.
+func managedServiceSettings(services *schema.Set) map[string]map[string]bool {
+	managed := make(map[string]map[string]bool)
+	for name, settings := range servicesConfigFromSet(services) {
+		managed[name] = make(map[string]bool)
+		for setting := range settings {
+			managed[name][setting] = true
+		}
+	}
+	return managed
+}
+
+// mergeServicesConfig merges the desired services configuration into the
+// current one. The settings that were previously managed are removed from
+// the current configuration first, so that the settings removed from the
+// `services` block are also removed from the sidecar.
+func mergeServicesConfig(
+	current, desired SidecarServicesConfig,
+	previouslyManaged map[string]map[string]bool,
+) SidecarServicesConfig {
+	merged := make(SidecarServicesConfig)
+	for name, settings := range current {
+		merged[name] = make(map[string]string)
+		for key, value := range settings {
+			if !previouslyManaged[name][key] {
+				merged[name][key] = value
+			}
+		}
+	}
+	for name, settings := range desired {
+		if _, ok := merged[name]; !ok {
+			merged[name] = make(map[string]string)
+		}
+		for key, value := range settings {
+			merged[name][key] = value
+		}
+	}
+	return merged
+}
+
+// flattenServices is the inverse of servicesConfigFromSet. Only the services
+// and settings in managed are flattened, so that the settings managed
+// outside of Terraform do not show as a diff.
+func flattenServices(config SidecarServicesConfig, managed map[string]map[string]bool) []interface{} {
+	var services []interface{}
+	for name, managedSettings := range managed {
+		settings := make(map[string]interface{})
+		for key, value := range config[name] {
+			if managedSettings[key] {
+				settings[key] = value
+			}
+		}
+		services = append(services, map[string]interface{}{
+			utils.NameKey: name,
+			SettingsKey:   settings,
+		})
+	}
+	return services
+}
+
+// validateServices ensures that each service is configured at most once and
+// that the `settings` argument does not override the bypass mode of the
+// dispatcher.
+func validateServices(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(ServicesKey) {
+		return nil
+	}
+	services, ok := d.Get(ServicesKey).(*schema.Set)
+	if !ok {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, serviceIface := range services.List() {
+		service := serviceIface.(map[string]interface{})
+		name := service[utils.NameKey].(string)
+		if seen[name] {
+			return fmt.Errorf("service %q is configured more than once in `%s`", name, ServicesKey)
+		}
+		seen[name] = true
+
+		settings, _ := service[SettingsKey].(map[string]interface{})
+		if _, ok := settings[bypassSetting]; ok && name == dispatcherService {
+			return fmt.Errorf(
+				"setting %q of service %q cannot be set through `%s`, use `%s` instead",
+				bypassSetting, name, SettingsKey, BypassModeKey,
+			)
+		}
+	}
+	return nil
+}
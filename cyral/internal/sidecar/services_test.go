@@ -0,0 +1,121 @@
+package sidecar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeServicesConfig(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		current           SidecarServicesConfig
+		desired           SidecarServicesConfig
+		previouslyManaged map[string]map[string]bool
+		expected          SidecarServicesConfig
+	}{
+		{
+			desc: "unmanaged settings are kept",
+			current: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover", "maxConnections": "100"},
+				"pg-wire":    {"logLevel": "info"},
+			},
+			desired: SidecarServicesConfig{
+				"dispatcher": {"bypass": "never", "logLevel": "debug"},
+			},
+			expected: SidecarServicesConfig{
+				"dispatcher": {"bypass": "never", "logLevel": "debug", "maxConnections": "100"},
+				"pg-wire":    {"logLevel": "info"},
+			},
+		},
+		{
+			desc: "settings removed from the configuration are removed",
+			current: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover"},
+				"pg-wire":    {"logLevel": "info", "idleTimeout": "30m", "cpuLimit": "2"},
+			},
+			desired: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover"},
+				"pg-wire":    {"logLevel": "debug"},
+			},
+			previouslyManaged: map[string]map[string]bool{
+				"pg-wire": {"logLevel": true, "idleTimeout": true},
+			},
+			expected: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover"},
+				"pg-wire":    {"logLevel": "debug", "cpuLimit": "2"},
+			},
+		},
+		{
+			desc:    "no current configuration",
+			current: nil,
+			desired: SidecarServicesConfig{
+				"dispatcher": {"bypass": "always"},
+			},
+			expected: SidecarServicesConfig{
+				"dispatcher": {"bypass": "always"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected,
+				mergeServicesConfig(testCase.current, testCase.desired, testCase.previouslyManaged))
+		})
+	}
+}
+
+func TestFlattenServices(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		config   SidecarServicesConfig
+		managed  map[string]map[string]bool
+		expected []interface{}
+	}{
+		{
+			desc: "unmanaged services and settings are ignored",
+			config: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover", "maxConnections": "100"},
+				"pg-wire":    {"logLevel": "info", "idleTimeout": "30m", "maxConnections": "10"},
+			},
+			managed: map[string]map[string]bool{
+				"pg-wire": {"logLevel": true, "maxConnections": true},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"name":     "pg-wire",
+					"settings": map[string]interface{}{"logLevel": "info", "maxConnections": "10"},
+				},
+			},
+		},
+		{
+			desc: "managed settings removed outside of Terraform are flattened empty",
+			config: SidecarServicesConfig{
+				"dispatcher": {"bypass": "failover"},
+			},
+			managed: map[string]map[string]bool{
+				"pg-wire": {"cpuLimit": true},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"name":     "pg-wire",
+					"settings": map[string]interface{}{},
+				},
+			},
+		},
+		{
+			desc: "no managed services",
+			config: SidecarServicesConfig{
+				"pg-wire": {"logLevel": "info"},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, flattenServices(testCase.config, testCase.managed))
+		})
+	}
+}
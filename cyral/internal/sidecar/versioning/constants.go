@@ -0,0 +1,54 @@
+package versioning
+
+import (
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+const (
+	dataSourceName = "cyral_sidecar_version_drift"
+)
+
+const (
+	// Schema keys
+	VersionKey                   = "version"
+	LatestVersionKey             = "latest_version"
+	IncludeUpToDateKey           = "include_up_to_date"
+	SidecarsKey                  = "sidecars"
+	InstancesKey                 = "instances"
+	InstanceIDKey                = "instance_id"
+	DynamicVersionKey            = "dynamic_version"
+	RecyclingKey                 = "recycling"
+	RecyclableKey                = "recyclable"
+	DriftKey                     = "drift"
+	MajorBehindKey               = "major_versions_behind"
+	MinorBehindKey               = "minor_versions_behind"
+	PatchBehindKey               = "patch_versions_behind"
+	OutdatedInstanceCountKey     = "outdated_instance_count"
+	UnknownDriftInstanceCountKey = "unknown_drift_instance_count"
+)
+
+type Drift string
+
+const (
+	DriftNone    = Drift("none")
+	DriftPatch   = Drift("patch")
+	DriftMinor   = Drift("minor")
+	DriftMajor   = Drift("major")
+	DriftUnknown = Drift("unknown")
+)
+
+func Drifts() []Drift {
+	return []Drift{
+		DriftNone,
+		DriftPatch,
+		DriftMinor,
+		DriftMajor,
+		DriftUnknown,
+	}
+}
+
+func DriftsAsString() []string {
+	return utils.ToSliceOfString[Drift](Drifts(), func(d Drift) string {
+		return string(d)
+	})
+}
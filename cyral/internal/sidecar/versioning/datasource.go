@@ -0,0 +1,213 @@
+package versioning
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/systeminfo"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the sidecar instances running behind the latest sidecar version available to the " +
+			"Control Plane, grouped by sidecar, which helps planning sidecar upgrades.",
+		ReadContext: dataSourceSidecarVersionDriftRead,
+		Schema: map[string]*schema.Schema{
+			utils.SidecarIDKey: {
+				Description: "Filter the results by sidecar ID. If omitted, all sidecars are considered.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			IncludeUpToDateKey: {
+				Description: "If `true`, instances running the latest version are also listed. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			utils.IDKey: {
+				Description: "Data source identifier.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			LatestVersionKey: {
+				Description: "Latest sidecar version available to the Control Plane.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			OutdatedInstanceCountKey: {
+				Description: "Number of instances, of all the sidecars considered, running behind the latest " +
+					"version. Instances whose drift is `" + string(DriftUnknown) + "` are not counted.",
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			UnknownDriftInstanceCountKey: {
+				Description: "Number of instances, of all the sidecars considered, whose drift is `" +
+					string(DriftUnknown) + "`, e.g. because their version or the latest version is not a " +
+					"valid semantic version.",
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			SidecarsKey: {
+				Description: "Sidecars with instances running behind the latest version or with an `" +
+					string(DriftUnknown) + "` drift, sorted by name. If `" + IncludeUpToDateKey +
+					"` is `true`, sidecars whose instances are all up to date are also listed.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						utils.SidecarIDKey: {
+							Description: "Sidecar identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						utils.NameKey: {
+							Description: "Sidecar name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						OutdatedInstanceCountKey: {
+							Description: "Number of instances of the sidecar running behind the latest version.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						UnknownDriftInstanceCountKey: {
+							Description: "Number of instances of the sidecar whose drift is `" +
+								string(DriftUnknown) + "`.",
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						InstancesKey: {
+							Description: "Instances of the sidecar running behind the latest version or with an `" +
+								string(DriftUnknown) + "` drift. If `" + IncludeUpToDateKey +
+								"` is `true`, up to date instances are also listed.",
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									InstanceIDKey: {
+										Description: "Instance identifier.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									VersionKey: {
+										Description: "Sidecar version that the instance is using.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									DynamicVersionKey: {
+										Description: "If true, the version of the instance is not fixed at template " +
+											"level and it can be automatically upgraded.",
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									RecyclingKey: {
+										Description: "Indicates whether the instance is being recycled.",
+										Type:        schema.TypeBool,
+										Computed:    true,
+									},
+									RecyclableKey: {
+										Description: "Indicates whether the instance can be recycled (e.g., by an ASG), " +
+											"which allows upgrading it by replacing it with a new instance.",
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									DriftKey: {
+										Description: "Most significant semantic version segment in which the instance " +
+											"is behind the latest version. List of possible values:" +
+											utils.SupportedValuesAsMarkdown(DriftsAsString()) +
+											"\n`" + string(DriftUnknown) + "` is used when the version of the instance or " +
+											"the latest version is not a valid semantic version.",
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									MajorBehindKey: {
+										Description: "Number of major versions behind the latest version.",
+										Type:        schema.TypeInt,
+										Computed:    true,
+									},
+									MinorBehindKey: {
+										Description: "Number of minor versions behind the latest version, if the major " +
+											"version is the latest one.",
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									PatchBehindKey: {
+										Description: "Number of patch versions behind the latest version, if the major " +
+											"and minor versions are the latest ones.",
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSidecarVersionDriftRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Init dataSourceSidecarVersionDriftRead")
+	c := m.(*client.Client)
+
+	systemInfo, err := systeminfo.GetSystemInfo(ctx, c)
+	if err != nil {
+		return utils.CreateError("Unable to retrieve the latest sidecar version", err.Error())
+	}
+
+	var sidecarsInfo []sidecar.IdentifiedSidecarInfo
+	if sidecarID := d.Get(utils.SidecarIDKey).(string); sidecarID != "" {
+		sidecarData, err := sidecar.GetSidecar(ctx, c, sidecarID)
+		if err != nil {
+			return utils.CreateError(fmt.Sprintf("Unable to retrieve sidecar %q", sidecarID), err.Error())
+		}
+		sidecarsInfo = append(sidecarsInfo, sidecar.IdentifiedSidecarInfo{ID: sidecarID, Sidecar: *sidecarData})
+	} else if sidecarsInfo, err = sidecar.ListSidecars(c); err != nil {
+		return utils.CreateError("Unable to retrieve the list of existent sidecars.", err.Error())
+	}
+
+	fleetVersions := &FleetVersions{
+		LatestVersion:   systemInfo.SidecarLatestVersion,
+		IncludeUpToDate: d.Get(IncludeUpToDateKey).(bool),
+	}
+	for _, sidecarInfo := range sidecarsInfo {
+		instances, err := instance.ListSidecarInstances(ctx, c, sidecarInfo.ID)
+		if err != nil {
+			if !client.IsNotFound(err) {
+				return utils.CreateError(
+					fmt.Sprintf("Unable to retrieve the instances of sidecar %q", sidecarInfo.ID), err.Error())
+			}
+		}
+		sidecarVersions := SidecarVersions{
+			SidecarID: sidecarInfo.ID,
+			Name:      sidecarInfo.Sidecar.Name,
+		}
+		for _, inst := range instances {
+			sidecarVersions.Instances = append(sidecarVersions.Instances,
+				newInstanceVersion(inst, systemInfo.SidecarLatestVersion))
+		}
+		fleetVersions.Sidecars = append(fleetVersions.Sidecars, sidecarVersions)
+	}
+	sort.SliceStable(fleetVersions.Sidecars, func(i, j int) bool {
+		return fleetVersions.Sidecars[i].Name < fleetVersions.Sidecars[j].Name
+	})
+
+	if err := fleetVersions.WriteToSchema(d); err != nil {
+		return utils.CreateError("Unable to read sidecar version drift", err.Error())
+	}
+	d.SetId(uuid.New().String())
+
+	tflog.Debug(ctx, "End dataSourceSidecarVersionDriftRead")
+	return nil
+}
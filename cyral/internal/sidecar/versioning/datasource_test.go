@@ -0,0 +1,46 @@
+package versioning_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/provider"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+const (
+	versionDriftDataSourceFullNameFmt = "data.cyral_sidecar_version_drift.%s"
+)
+
+func TestAccSidecarVersionDriftDataSource(t *testing.T) {
+	dataSourceName := "version_drift"
+	dataSourceFullName := fmt.Sprintf(versionDriftDataSourceFullNameFmt, dataSourceName)
+	config := utils.FormatBasicSidecarIntoConfig(
+		utils.BasicSidecarResName,
+		utils.AccTestName("sidecar-version-drift", "sidecar"),
+		"docker", "",
+	) + fmt.Sprintf(`
+	data "cyral_sidecar_version_drift" "%s" {
+		sidecar_id         = %s
+		include_up_to_date = true
+	}`, dataSourceName, utils.BasicSidecarID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: provider.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The sidecar has no instances, so there is nothing to
+				// upgrade.
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceFullName, "latest_version"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "outdated_instance_count", "0"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "unknown_drift_instance_count", "0"),
+					resource.TestCheckResourceAttr(dataSourceFullName, "sidecars.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,175 @@
+package versioning
+
+import (
+	"fmt"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
+	"github.com/cyralinc/terraform-provider-cyral/cyral/utils"
+)
+
+// VersionDrift is the distance from a version to the latest version. Only
+// the most significant segment that differs is counted, e.g. `v4.1.5` is one
+// minor version behind `v4.2.0`.
+type VersionDrift struct {
+	Drift       Drift
+	MajorBehind int
+	MinorBehind int
+	PatchBehind int
+}
+
+// computeVersionDrift compares the given version to the latest version.
+// Versions that can not be parsed as semantic versions result in an unknown
+// drift, and versions ahead of the latest version are considered up to date.
+func computeVersionDrift(current, latest string) VersionDrift {
+	currentVersion, err := goversion.NewSemver(current)
+	if err != nil {
+		return VersionDrift{Drift: DriftUnknown}
+	}
+	latestVersion, err := goversion.NewSemver(latest)
+	if err != nil {
+		return VersionDrift{Drift: DriftUnknown}
+	}
+	if !currentVersion.LessThan(latestVersion) {
+		return VersionDrift{Drift: DriftNone}
+	}
+	c, l := currentVersion.Segments(), latestVersion.Segments()
+	switch {
+	case c[0] != l[0]:
+		return VersionDrift{Drift: DriftMajor, MajorBehind: l[0] - c[0]}
+	case c[1] != l[1]:
+		return VersionDrift{Drift: DriftMinor, MinorBehind: l[1] - c[1]}
+	case c[2] != l[2]:
+		return VersionDrift{Drift: DriftPatch, PatchBehind: l[2] - c[2]}
+	}
+	// Same release, but the current version is a prerelease of the latest.
+	return VersionDrift{Drift: DriftPatch}
+}
+
+// isOutdated reports whether the version is known to be behind the latest
+// version. Unknown drifts are not considered outdated, see isUnknown.
+func (v VersionDrift) isOutdated() bool {
+	return v.Drift != DriftNone && v.Drift != DriftUnknown
+}
+
+func (v VersionDrift) isUnknown() bool {
+	return v.Drift == DriftUnknown
+}
+
+// InstanceVersion is the version of a sidecar instance, along with the
+// properties that determine how it can be upgraded.
+type InstanceVersion struct {
+	InstanceID     string
+	Version        string
+	DynamicVersion bool
+	Recycling      bool
+	Recyclable     bool
+	VersionDrift
+}
+
+func newInstanceVersion(inst instance.SidecarInstance, latest string) InstanceVersion {
+	recyclable := false
+	if inst.Metadata.SidecarCapabilities != nil {
+		recyclable = inst.Metadata.SidecarCapabilities.Recyclable
+	}
+	return InstanceVersion{
+		InstanceID:     inst.ID,
+		Version:        inst.Metadata.Version,
+		DynamicVersion: inst.Metadata.IsDynamicVersion,
+		Recycling:      inst.Metadata.IsRecycling,
+		Recyclable:     recyclable,
+		VersionDrift:   computeVersionDrift(inst.Metadata.Version, latest),
+	}
+}
+
+func (iv *InstanceVersion) ToMap() map[string]any {
+	return map[string]any{
+		InstanceIDKey:     iv.InstanceID,
+		VersionKey:        iv.Version,
+		DynamicVersionKey: iv.DynamicVersion,
+		RecyclingKey:      iv.Recycling,
+		RecyclableKey:     iv.Recyclable,
+		DriftKey:          string(iv.Drift),
+		MajorBehindKey:    iv.MajorBehind,
+		MinorBehindKey:    iv.MinorBehind,
+		PatchBehindKey:    iv.PatchBehind,
+	}
+}
+
+type SidecarVersions struct {
+	SidecarID string
+	Name      string
+	Instances []InstanceVersion
+}
+
+func (sv *SidecarVersions) outdatedInstances() int {
+	outdated := 0
+	for _, iv := range sv.Instances {
+		if iv.isOutdated() {
+			outdated++
+		}
+	}
+	return outdated
+}
+
+func (sv *SidecarVersions) unknownDriftInstances() int {
+	unknown := 0
+	for _, iv := range sv.Instances {
+		if iv.isUnknown() {
+			unknown++
+		}
+	}
+	return unknown
+}
+
+func (sv *SidecarVersions) ToMap(includeUpToDate bool) map[string]any {
+	instances := make([]any, 0, len(sv.Instances))
+	for _, iv := range sv.Instances {
+		if includeUpToDate || iv.isOutdated() || iv.isUnknown() {
+			instances = append(instances, iv.ToMap())
+		}
+	}
+	return map[string]any{
+		utils.SidecarIDKey:           sv.SidecarID,
+		utils.NameKey:                sv.Name,
+		OutdatedInstanceCountKey:     sv.outdatedInstances(),
+		UnknownDriftInstanceCountKey: sv.unknownDriftInstances(),
+		InstancesKey:                 instances,
+	}
+}
+
+// FleetVersions is the version of the instances of a set of sidecars,
+// compared to the latest sidecar version.
+type FleetVersions struct {
+	LatestVersion   string
+	Sidecars        []SidecarVersions
+	IncludeUpToDate bool
+}
+
+func (fv *FleetVersions) WriteToSchema(d *schema.ResourceData) error {
+	sidecars := make([]any, 0, len(fv.Sidecars))
+	outdated, unknown := 0, 0
+	for _, sv := range fv.Sidecars {
+		sidecarOutdated, sidecarUnknown := sv.outdatedInstances(), sv.unknownDriftInstances()
+		outdated += sidecarOutdated
+		unknown += sidecarUnknown
+		if sidecarOutdated == 0 && sidecarUnknown == 0 && !(fv.IncludeUpToDate && len(sv.Instances) > 0) {
+			continue
+		}
+		sidecars = append(sidecars, sv.ToMap(fv.IncludeUpToDate))
+	}
+	fields := map[string]any{
+		LatestVersionKey:             fv.LatestVersion,
+		SidecarsKey:                  sidecars,
+		OutdatedInstanceCountKey:     outdated,
+		UnknownDriftInstanceCountKey: unknown,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf(utils.ErrorSettingFieldFmt, key, err)
+		}
+	}
+	return nil
+}
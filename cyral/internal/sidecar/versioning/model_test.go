@@ -0,0 +1,192 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeVersionDrift(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		current  string
+		latest   string
+		expected VersionDrift
+	}{
+		{
+			desc:     "same version",
+			current:  "v4.15.0",
+			latest:   "v4.15.0",
+			expected: VersionDrift{Drift: DriftNone},
+		},
+		{
+			desc:     "ahead of the latest version",
+			current:  "v4.16.0",
+			latest:   "v4.15.2",
+			expected: VersionDrift{Drift: DriftNone},
+		},
+		{
+			desc:     "patch versions behind",
+			current:  "v4.15.1",
+			latest:   "v4.15.3",
+			expected: VersionDrift{Drift: DriftPatch, PatchBehind: 2},
+		},
+		{
+			desc:     "only the minor versions are counted",
+			current:  "v4.1.5",
+			latest:   "v4.2.0",
+			expected: VersionDrift{Drift: DriftMinor, MinorBehind: 1},
+		},
+		{
+			desc:     "only the major versions are counted",
+			current:  "v3.9.9",
+			latest:   "v5.0.1",
+			expected: VersionDrift{Drift: DriftMajor, MajorBehind: 2},
+		},
+		{
+			desc:     "without the v prefix",
+			current:  "4.14.0",
+			latest:   "v4.15.0",
+			expected: VersionDrift{Drift: DriftMinor, MinorBehind: 1},
+		},
+		{
+			desc:     "prerelease of the latest version",
+			current:  "v4.15.0-rc1",
+			latest:   "v4.15.0",
+			expected: VersionDrift{Drift: DriftPatch},
+		},
+		{
+			desc:     "unparsable current version",
+			current:  "latest",
+			latest:   "v4.15.0",
+			expected: VersionDrift{Drift: DriftUnknown},
+		},
+		{
+			desc:     "empty current version",
+			current:  "",
+			latest:   "v4.15.0",
+			expected: VersionDrift{Drift: DriftUnknown},
+		},
+		{
+			desc:     "unparsable latest version",
+			current:  "v4.15.0",
+			latest:   "unknown",
+			expected: VersionDrift{Drift: DriftUnknown},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, computeVersionDrift(testCase.current, testCase.latest))
+		})
+	}
+}
+
+func TestFleetVersionsWriteToSchema(t *testing.T) {
+	sidecar := func(id string, versions ...string) SidecarVersions {
+		sv := SidecarVersions{SidecarID: id, Name: id}
+		for i, version := range versions {
+			sv.Instances = append(sv.Instances, InstanceVersion{
+				InstanceID:   id + "-" + string(rune('a'+i)),
+				Version:      version,
+				VersionDrift: computeVersionDrift(version, "v4.15.0"),
+			})
+		}
+		return sv
+	}
+
+	testCases := []struct {
+		desc              string
+		fleetVersions     FleetVersions
+		expectedOutdated  int
+		expectedUnknown   int
+		expectedSidecars  []string
+		expectedInstances map[string]int
+	}{
+		{
+			desc: "up to date sidecars are not listed",
+			fleetVersions: FleetVersions{
+				LatestVersion: "v4.15.0",
+				Sidecars: []SidecarVersions{
+					sidecar("outdated", "v4.14.0", "v4.15.0"),
+					sidecar("up-to-date", "v4.15.0"),
+				},
+			},
+			expectedOutdated:  1,
+			expectedSidecars:  []string{"outdated"},
+			expectedInstances: map[string]int{"outdated": 1},
+		},
+		{
+			desc: "unknown drifts are counted apart from outdated instances",
+			fleetVersions: FleetVersions{
+				LatestVersion: "v4.15.0",
+				Sidecars: []SidecarVersions{
+					sidecar("unknown", "latest", "v4.15.0"),
+					sidecar("outdated", "v4.14.0", ""),
+				},
+			},
+			expectedOutdated:  1,
+			expectedUnknown:   2,
+			expectedSidecars:  []string{"unknown", "outdated"},
+			expectedInstances: map[string]int{"unknown": 1, "outdated": 2},
+		},
+		{
+			desc: "empty latest version does not make the fleet outdated",
+			fleetVersions: FleetVersions{
+				Sidecars: []SidecarVersions{
+					{
+						SidecarID: "sidecar",
+						Name:      "sidecar",
+						Instances: []InstanceVersion{
+							{
+								InstanceID:   "instance",
+								Version:      "v4.15.0",
+								VersionDrift: computeVersionDrift("v4.15.0", ""),
+							},
+						},
+					},
+				},
+			},
+			expectedUnknown:   1,
+			expectedSidecars:  []string{"sidecar"},
+			expectedInstances: map[string]int{"sidecar": 1},
+		},
+		{
+			desc: "up to date instances are listed when requested",
+			fleetVersions: FleetVersions{
+				LatestVersion: "v4.15.0",
+				Sidecars: []SidecarVersions{
+					sidecar("outdated", "v4.14.0", "v4.15.0"),
+					sidecar("up-to-date", "v4.15.0"),
+					sidecar("no-instances"),
+				},
+				IncludeUpToDate: true,
+			},
+			expectedOutdated:  1,
+			expectedSidecars:  []string{"outdated", "up-to-date"},
+			expectedInstances: map[string]int{"outdated": 2, "up-to-date": 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceSchema().Schema, map[string]interface{}{})
+			require.NoError(t, testCase.fleetVersions.WriteToSchema(d))
+
+			assert.Equal(t, testCase.fleetVersions.LatestVersion, d.Get(LatestVersionKey))
+			assert.Equal(t, testCase.expectedOutdated, d.Get(OutdatedInstanceCountKey))
+			assert.Equal(t, testCase.expectedUnknown, d.Get(UnknownDriftInstanceCountKey))
+			sidecars := d.Get(SidecarsKey).([]interface{})
+			var sidecarIDs []string
+			for _, s := range sidecars {
+				sidecarMap := s.(map[string]interface{})
+				sidecarID := sidecarMap["sidecar_id"].(string)
+				sidecarIDs = append(sidecarIDs, sidecarID)
+				assert.Len(t, sidecarMap[InstancesKey], testCase.expectedInstances[sidecarID], sidecarID)
+			}
+			assert.Equal(t, testCase.expectedSidecars, sidecarIDs)
+		})
+	}
+}
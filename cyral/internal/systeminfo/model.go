@@ -1,8 +1,16 @@
 package systeminfo
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cyralinc/terraform-provider-cyral/cyral/client"
 )
 
 type SystemInfo struct {
@@ -17,3 +25,21 @@ func (systemInfo *SystemInfo) WriteToSchema(d *schema.ResourceData) error {
 
 	return nil
 }
+
+// GetSystemInfo retrieves the information of the Cyral system, such as the
+// latest sidecar version available to the control plane.
+func GetSystemInfo(ctx context.Context, c *client.Client) (*SystemInfo, error) {
+	tflog.Debug(ctx, "Init GetSystemInfo")
+	url := fmt.Sprintf("https://%s/v1/systemInfo", c.ControlPlane)
+	body, err := c.DoRequest(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	systemInfo := &SystemInfo{}
+	if err := json.Unmarshal(body, systemInfo); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Response body (unmarshaled): %#v", systemInfo))
+	tflog.Debug(ctx, "End GetSystemInfo")
+	return systemInfo, nil
+}
@@ -22,11 +22,15 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository"
 	repository_accessgateway "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/accessgateway"
 	repository_accessrules "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/accessrules"
+	repository_accessrules_rule "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/accessrules/rule"
 	repository_binding "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/binding"
+	repository_bulk "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/bulk"
 	repository_confanalysis "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confanalysis"
 	repository_confauth "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/confauth"
 	repository_datamap "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/datamap"
+	repository_datamap_mapping "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/datamap/mapping"
 	repository_network "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/network"
+	repository_stack "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/stack"
 	repository_useraccount "github.com/cyralinc/terraform-provider-cyral/cyral/internal/repository/useraccount"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/role"
 	role_ssogroups "github.com/cyralinc/terraform-provider-cyral/cyral/internal/role/ssogroups"
@@ -35,9 +39,11 @@ import (
 	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar"
 	sidecar_credentials "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/credentials"
 	sidecar_health "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health"
+	sidecar_health_fleet "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/health/fleet"
 	sidecar_instance "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance"
 	sidecar_instance_stats "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/instance/stats"
 	sidecar_listener "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/listener"
+	sidecar_versioning "github.com/cyralinc/terraform-provider-cyral/cyral/internal/sidecar/versioning"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/systeminfo"
 	"github.com/cyralinc/terraform-provider-cyral/cyral/internal/tokensettings"
 )
@@ -64,11 +70,15 @@ func packagesSchemas() []core.PackageSchema {
 		repository.PackageSchema(),
 		repository_accessgateway.PackageSchema(),
 		repository_accessrules.PackageSchema(),
+		repository_accessrules_rule.PackageSchema(),
 		repository_binding.PackageSchema(),
+		repository_bulk.PackageSchema(),
 		repository_confanalysis.PackageSchema(),
 		repository_confauth.PackageSchema(),
 		repository_datamap.PackageSchema(),
+		repository_datamap_mapping.PackageSchema(),
 		repository_network.PackageSchema(),
+		repository_stack.PackageSchema(),
 		repository_useraccount.PackageSchema(),
 		role.PackageSchema(),
 		role_ssogroups.PackageSchema(),
@@ -77,9 +87,11 @@ func packagesSchemas() []core.PackageSchema {
 		sidecar.PackageSchema(),
 		sidecar_credentials.PackageSchema(),
 		sidecar_health.PackageSchema(),
+		sidecar_health_fleet.PackageSchema(),
 		sidecar_listener.PackageSchema(),
 		sidecar_instance.PackageSchema(),
 		sidecar_instance_stats.PackageSchema(),
+		sidecar_versioning.PackageSchema(),
 		systeminfo.PackageSchema(),
 		tokensettings.PackageSchema(),
 	}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// The functions below read the attributes of the raw configuration given to
+// ValidateRawResourceConfigFuncs, which is validated before the plan is
+// computed. Each one returns false when the attribute is unknown, in which
+// case the validations that depend on it should be skipped.
+
+// RawConfigString returns the value of the given string attribute of a
+// configuration object. Null values are returned as an empty string.
+func RawConfigString(obj cty.Value, key string) (string, bool) {
+	v, ok := rawConfigAttr(obj, key)
+	if !ok {
+		return "", false
+	}
+	if v.IsNull() {
+		return "", true
+	}
+	return v.AsString(), true
+}
+
+// RawConfigBool returns the value of the given bool attribute of a
+// configuration object, or defaultValue if the attribute is not set.
+func RawConfigBool(obj cty.Value, key string, defaultValue bool) (bool, bool) {
+	v, ok := rawConfigAttr(obj, key)
+	if !ok {
+		return false, false
+	}
+	if v.IsNull() {
+		return defaultValue, true
+	}
+	return v.True(), true
+}
+
+// RawConfigStrings returns the elements of the given list or set of strings
+// of a configuration object.
+func RawConfigStrings(obj cty.Value, key string) ([]string, bool) {
+	v, ok := rawConfigAttr(obj, key)
+	if !ok || !v.IsWhollyKnown() {
+		return nil, false
+	}
+	if v.IsNull() {
+		return nil, true
+	}
+	var values []string
+	for it := v.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if elem.IsNull() {
+			continue
+		}
+		values = append(values, elem.AsString())
+	}
+	return values, true
+}
+
+// RawConfigBlocks returns the objects of the given list or set of nested
+// blocks of a configuration object.
+func RawConfigBlocks(obj cty.Value, key string) ([]cty.Value, bool) {
+	v, ok := rawConfigAttr(obj, key)
+	if !ok {
+		return nil, false
+	}
+	if v.IsNull() {
+		return nil, true
+	}
+	var blocks []cty.Value
+	for it := v.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		blocks = append(blocks, elem)
+	}
+	return blocks, true
+}
+
+func rawConfigAttr(obj cty.Value, key string) (cty.Value, bool) {
+	if !obj.IsKnown() || obj.IsNull() || !obj.Type().IsObjectType() || !obj.Type().HasAttribute(key) {
+		return cty.NilVal, false
+	}
+	v := obj.GetAttr(key)
+	return v, v.IsKnown()
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -65,6 +66,43 @@ func UrlQuery(kv map[string]string) string {
 	return queryStr
 }
 
+// Values of the `labels_match` argument of the data sources that filter
+// their results by labels.
+const (
+	LabelsMatchAll = "all"
+	LabelsMatchAny = "any"
+)
+
+func LabelsMatchModes() []string {
+	return []string{
+		LabelsMatchAll,
+		LabelsMatchAny,
+	}
+}
+
+// MatchLabels returns true if labels contain all the wanted labels or, if
+// labelsMatch is LabelsMatchAny, at least one of them. An empty list of wanted
+// labels matches any labels.
+func MatchLabels(labels, wanted []string, labelsMatch string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	present := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		present[label] = true
+	}
+	matched := 0
+	for _, label := range wanted {
+		if present[label] {
+			matched++
+		}
+	}
+	if labelsMatch == LabelsMatchAny {
+		return matched > 0
+	}
+	return matched == len(wanted)
+}
+
 func ElementsMatch(this, other []string) bool {
 	if len(this) != len(other) {
 		return false
@@ -247,3 +285,27 @@ func StringTimestampFromProto(ts *timestamppb.Timestamp) string {
 	}
 	return ts.AsTime().Format(time.RFC3339)
 }
+
+var keyLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// LockKey acquires the lock identified by the given key, creating it if it
+// doesn't exist, and returns the function that releases it. It is used by
+// resources that manage a single entry of a remote object through
+// read-modify-write, to serialize the concurrent operations on that object.
+// The lock is only held within the provider process, so it does not
+// serialize the operations of concurrent Terraform runs.
+func LockKey(key string) func() {
+	keyLocks.Lock()
+	lock, ok := keyLocks.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		keyLocks.locks[key] = lock
+	}
+	keyLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
@@ -47,3 +47,61 @@ func TestElementsMatch(t *testing.T) {
 	}
 
 }
+
+func TestMatchLabels(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		labels      []string
+		wanted      []string
+		labelsMatch string
+		expectMatch bool
+	}{
+		{
+			desc:        "no wanted labels",
+			labels:      []string{"a"},
+			labelsMatch: utils.LabelsMatchAll,
+			expectMatch: true,
+		},
+		{
+			desc:        "all wanted labels present",
+			labels:      []string{"a", "b", "c"},
+			wanted:      []string{"c", "a"},
+			labelsMatch: utils.LabelsMatchAll,
+			expectMatch: true,
+		},
+		{
+			desc:        "some wanted labels missing",
+			labels:      []string{"a"},
+			wanted:      []string{"a", "b"},
+			labelsMatch: utils.LabelsMatchAll,
+			expectMatch: false,
+		},
+		{
+			desc:        "empty mode behaves as all",
+			labels:      []string{"a"},
+			wanted:      []string{"a", "b"},
+			expectMatch: false,
+		},
+		{
+			desc:        "any wanted label present",
+			labels:      []string{"b"},
+			wanted:      []string{"a", "b"},
+			labelsMatch: utils.LabelsMatchAny,
+			expectMatch: true,
+		},
+		{
+			desc:        "no wanted label present",
+			labels:      []string{"c"},
+			wanted:      []string{"a", "b"},
+			labelsMatch: utils.LabelsMatchAny,
+			expectMatch: false,
+		},
+	}
+	for _, testCase := range testCases {
+		match := utils.MatchLabels(testCase.labels, testCase.wanted, testCase.labelsMatch)
+		if match != testCase.expectMatch {
+			t.Errorf("For test %q, expected match=%t got match=%t",
+				testCase.desc, testCase.expectMatch, match)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	awsSecretARNRegex = regexp.MustCompile(
+		`^arn:aws[a-z-]*:secretsmanager:[a-z]{2}(-[a-z]+)+-\d+:\d{12}:secret:[\w/+=.@-]{1,512}$`)
+)
+
+func ValidationAWSSecretARN() schema.SchemaValidateFunc {
+	return validation.StringMatch(awsSecretARNRegex,
+		"must be an AWS Secrets Manager secret ARN, such as "+
+			"`arn:aws:secretsmanager:us-east-1:123456789012:secret:my-secret`")
+}
+
+// ValidationParsableDuration validates that a string is a duration in the
+// format accepted by time.ParseDuration, such as `30s` or `1h`. Negative
+// durations are always rejected, and zero is rejected unless allowZero is set.
+func ValidationParsableDuration(allowZero bool) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		}
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration < 0 || (duration == 0 && !allowZero) {
+			qualifier := "positive"
+			if allowZero {
+				qualifier = "non-negative"
+			}
+			return nil, []error{fmt.Errorf("%q must be a %s duration, such as `30s` or `1h`, got %q",
+				k, qualifier, v)}
+		}
+		return nil, nil
+	}
+}
@@ -0,0 +1,30 @@
+resource "cyral_repository" "sqlserver" {
+  name = "sqlserver"
+  type = "sqlserver"
+
+  repo_node {
+    host = "sqlserver.mycompany.com"
+    port = 1433
+  }
+}
+
+resource "cyral_repository_network_access_policy" "policy" {
+  repository_id = cyral_repository.sqlserver.id
+
+  network_access_rule {
+    name        = "office"
+    db_accounts = ["reader"]
+    source_ips  = ["10.0.0.0/24", "2001:db8::/32"]
+  }
+}
+
+# Checks whether the reader account can connect from the office network.
+data "cyral_network_access_evaluation" "office_reader" {
+  repository_id = cyral_repository_network_access_policy.policy.repository_id
+  source_ip     = "10.0.0.42"
+  db_account    = "reader"
+}
+
+output "office_reader_decision" {
+  value = "${data.cyral_network_access_evaluation.office_reader.decision} (${data.cyral_network_access_evaluation.office_reader.reason})"
+}
@@ -61,3 +61,16 @@ output "mysql1_repo_id" {
 output "all_mysql_repo_ids" {
   value = data.cyral_repository.all-mysql-repos.repository_list
 }
+
+data "cyral_repository" "prod-repos-in-us-east" {
+  # Repositories that have both labels and at least one
+  # node hosted in the `cyral.com` domain.
+  labels = ["prod", "us-east-1"]
+  host   = "\\.cyral\\.com$"
+}
+
+data "cyral_repository" "team-repos" {
+  # Repositories that have at least one of the labels.
+  labels       = ["team-a", "team-b"]
+  labels_match = "any"
+}
@@ -0,0 +1,29 @@
+resource "cyral_repository_datamap" "datamap" {
+  repository_id = cyral_repository.repo.id
+  mapping {
+    label      = "CCN"
+    attributes = ["finance.cards.ccn"]
+  }
+}
+
+# Lists the mappings found by the repository crawler that are not yet
+# managed by the cyral_repository_datamap resource above.
+data "cyral_repository_datamap" "datamap" {
+  repository_id = cyral_repository.repo.id
+
+  dynamic "desired_mapping" {
+    for_each = cyral_repository_datamap.datamap.mapping
+    content {
+      label      = desired_mapping.value.label
+      attributes = desired_mapping.value.attributes
+    }
+  }
+}
+
+output "unmanaged_labels" {
+  value = data.cyral_repository_datamap.datamap.missing_labels
+}
+
+output "unmanaged_mappings" {
+  value = data.cyral_repository_datamap.datamap.missing_mapping
+}
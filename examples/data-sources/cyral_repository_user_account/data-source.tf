@@ -0,0 +1,24 @@
+# Lists all the user accounts of the repository that retrieve their
+# credentials from AWS Secrets Manager.
+data "cyral_repository_user_account" "aws_secrets" {
+  repository_id    = cyral_repository.repo.id
+  auth_scheme_type = "aws_secrets_manager"
+}
+
+# Grants the analytics group access to every user account whose name starts
+# with "reader-", including the ones created outside of this configuration.
+data "cyral_repository_user_account" "readers" {
+  repository_id = cyral_repository.repo.id
+  name          = "^reader-"
+}
+
+resource "cyral_repository_access_rule" "analytics" {
+  for_each = {
+    for account in data.cyral_repository_user_account.readers.user_account_list :
+    account.name => account.user_account_id
+  }
+  repository_id   = cyral_repository.repo.id
+  user_account_id = each.value
+  identity_type   = "group"
+  identity_name   = "analytics"
+}
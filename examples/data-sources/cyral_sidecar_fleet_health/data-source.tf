@@ -0,0 +1,18 @@
+data "cyral_sidecar_fleet_health" "production" {
+  labels       = ["production"]
+  only_failing = true
+}
+
+check "production_sidecars_healthy" {
+  assert {
+    condition = data.cyral_sidecar_fleet_health.production.failing_component_count == 0
+    error_message = join("\n", [
+      for c in data.cyral_sidecar_fleet_health.production.components :
+      "${c.sidecar_name}/${c.instance_id}/${c.service}/${c.component}: ${c.status} ${c.error}"
+    ])
+  }
+}
+
+output "unhealthy_sidecars" {
+  value = lookup(data.cyral_sidecar_fleet_health.production.sidecar_status_counts, "UNHEALTHY", 0)
+}
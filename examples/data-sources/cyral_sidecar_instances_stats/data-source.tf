@@ -0,0 +1,17 @@
+data "cyral_sidecar_instances_stats" "some_data_source_name" {
+  sidecar_id = cyral_sidecar.some_resource_name.id
+}
+
+check "sidecar_connections_within_capacity" {
+  assert {
+    condition = alltrue([
+      for s in data.cyral_sidecar_instances_stats.some_data_source_name.instance_stats :
+      s.active_connections < 1000
+    ])
+    error_message = "Some sidecar instances have 1000 or more active connections, consider scaling out."
+  }
+}
+
+output "sidecar_average_queries_per_second" {
+  value = one(data.cyral_sidecar_instances_stats.some_data_source_name.queries_per_second[*].avg)
+}
@@ -0,0 +1,20 @@
+data "cyral_sidecar_version_drift" "all" {
+}
+
+# Instances that are a major version behind and can be upgraded
+# by recycling them.
+output "recyclable_major_upgrades" {
+  value = flatten([
+    for sidecar in data.cyral_sidecar_version_drift.all.sidecars : [
+      for instance in sidecar.instances : "${sidecar.name}/${instance.instance_id}"
+      if instance.drift == "major" && instance.recyclable
+    ]
+  ])
+}
+
+check "sidecars_up_to_date" {
+  assert {
+    condition = data.cyral_sidecar_version_drift.all.outdated_instance_count == 0
+    error_message = "Some sidecar instances are running behind ${data.cyral_sidecar_version_drift.all.latest_version}."
+  }
+}
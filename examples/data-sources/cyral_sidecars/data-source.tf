@@ -0,0 +1,33 @@
+# All the production sidecars deployed with Helm.
+data "cyral_sidecars" "prod_helm" {
+  labels            = ["prod"]
+  deployment_method = "helm3"
+}
+
+resource "cyral_repository" "pg" {
+  name = "pg"
+  type = "postgresql"
+  repo_node {
+    host = "pg.example.com"
+    port = 5432
+  }
+}
+
+# One listener and one binding per selected sidecar.
+resource "cyral_sidecar_listener" "pg" {
+  for_each   = toset(data.cyral_sidecars.prod_helm.ids)
+  sidecar_id = each.value
+  repo_types = ["postgresql"]
+  network_address {
+    port = 5432
+  }
+}
+
+resource "cyral_repository_binding" "pg" {
+  for_each      = toset(data.cyral_sidecars.prod_helm.ids)
+  sidecar_id    = each.value
+  repository_id = cyral_repository.pg.id
+  listener_binding {
+    listener_id = cyral_sidecar_listener.pg[each.value].listener_id
+  }
+}
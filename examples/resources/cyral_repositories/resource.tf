@@ -0,0 +1,40 @@
+locals {
+  # Usually loaded from an inventory file, e.g. with `yamldecode(file(...))`.
+  inventory = {
+    "orders-db" = {
+      type   = "postgresql"
+      host   = "orders.example.com"
+      port   = 5432
+      labels = ["team-orders", "prod"]
+    }
+    "billing-db" = {
+      type   = "mysql"
+      host   = "billing.example.com"
+      port   = 3306
+      labels = ["team-billing", "prod"]
+    }
+  }
+}
+
+resource "cyral_repositories" "fleet" {
+  parallelism = 20
+
+  dynamic "repository" {
+    for_each = local.inventory
+    content {
+      key    = repository.key
+      type   = repository.value.type
+      name   = repository.key
+      labels = repository.value.labels
+
+      repo_node {
+        host = repository.value.host
+        port = repository.value.port
+      }
+    }
+  }
+}
+
+output "orders_db_id" {
+  value = cyral_repositories.fleet.repository_ids["orders-db"]
+}
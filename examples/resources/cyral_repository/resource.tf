@@ -64,3 +64,29 @@ resource "cyral_repository" "multi_node_mongo_repo" {
       server_type = "replicaset"
     }
 }
+
+resource "cyral_repository" "oracle-repository" {
+  type = "oracle"
+  name = "tf-provider-oracle-repository"
+
+  repo_node {
+    host = "oracle.cyral.com"
+    port = 1521
+  }
+  oracle_settings {
+    service_name = "ORCLPDB1"
+  }
+}
+
+resource "cyral_repository" "pg-repository" {
+  type = "postgresql"
+  name = "tf-provider-pg-repository"
+
+  repo_node {
+    host = "pg.cyral.com"
+    port = 5432
+  }
+  postgresql_settings {
+    ssl_mode = "verify-full"
+  }
+}
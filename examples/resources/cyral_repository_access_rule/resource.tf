@@ -0,0 +1,10 @@
+# Grants the analytics group access to an existing user account, without
+# changing the access rules managed by other configurations.
+resource "cyral_repository_access_rule" "analytics" {
+  repository_id   = cyral_repository.repo.id
+  user_account_id = cyral_repository_user_account.reader.user_account_id
+  identity_type   = "group"
+  identity_name   = "analytics"
+  valid_from      = "2024-01-01T00:00:00Z"
+  valid_until     = "2024-12-31T23:59:59Z"
+}
@@ -57,4 +57,13 @@ resource "cyral_repository_binding" "binding_pg" {
   listener_binding {
     listener_id = cyral_sidecar_listener.listener_pg.listener_id
   }
+  // wait for the sidecar to be healthy before downstream
+  // steps, such as smoke tests, use the binding
+  wait_for_healthy {
+    min_instances = 2
+  }
+  timeouts {
+    create = "15m"
+    update = "15m"
+  }
 }
@@ -0,0 +1,15 @@
+# Each team manages its own labels of the same repository data map.
+resource "cyral_repository_datamap_mapping" "payments_ccn" {
+  repository_id = cyral_repository.repo.id
+  label         = "CCN"
+  attributes    = ["finance.cards.ccn"]
+}
+
+resource "cyral_repository_datamap_mapping" "hr_ssn" {
+  repository_id = cyral_repository.repo.id
+  label         = "SSN"
+  attributes = [
+    "hr.employees.ssn",
+    "hr.contractors.ssn",
+  ]
+}
@@ -0,0 +1,33 @@
+resource "cyral_sidecar" "sidecar" {
+  name              = "my-sidecar"
+  deployment_method = "terraform"
+}
+
+# Creates a PostgreSQL repository accessible through the sidecar on
+# port 5432, with TLS enforced for the clients.
+resource "cyral_repository_stack" "pg" {
+  sidecar_id = cyral_sidecar.sidecar.id
+
+  repository {
+    type = "postgresql"
+    name = "my-postgresql"
+    repo_node {
+      host = "postgresql.mycompany.com"
+      port = 5432
+    }
+  }
+
+  listener {
+    port = 5432
+  }
+
+  conf_auth {
+    client_tls = "enable"
+    repo_tls   = "enable"
+  }
+
+  conf_analysis {
+    redact     = "all"
+    log_groups = ["everything"]
+  }
+}
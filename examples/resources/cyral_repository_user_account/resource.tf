@@ -17,7 +17,7 @@ resource "cyral_repository_user_account" "aws_iam" {
 
   auth_scheme {
     aws_iam {
-      role_arn = "role_arn"
+      role_arn = "arn:aws:iam::123456789012:role/db-role"
     }
   }
 }
@@ -29,7 +29,7 @@ resource "cyral_repository_user_account" "aws_secrets" {
 
   auth_scheme {
     aws_secrets_manager {
-      secret_arn = "secret_arn"
+      secret_arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-secret"
     }
   }
 }
@@ -53,7 +53,7 @@ resource "cyral_repository_user_account" "gcp_secrets" {
 
   auth_scheme {
     gcp_secrets_manager {
-      secret_name = "secret_name"
+      secret_name = "projects/my-project/secrets/db-secret"
     }
   }
 }
@@ -95,3 +95,30 @@ resource "cyral_repository_user_account" "kubernetes" {
     }
   }
 }
+
+# cyral_repository_user_account with auth scheme cyberark_conjur will be created
+resource "cyral_repository_user_account" "cyberark_conjur" {
+  name          = "hbf_cyberark_conjur"
+  repository_id = cyral_repository.tf_test_repo.id
+
+  auth_scheme {
+    cyberark_conjur {
+      account     = "my-account"
+      variable_id = "my-app/db/password"
+    }
+  }
+}
+
+# cyral_repository_user_account with auth scheme delinea_secret_server will be created
+resource "cyral_repository_user_account" "delinea_secret_server" {
+  name          = "hbf_delinea_secret_server"
+  repository_id = cyral_repository.tf_test_repo.id
+
+  auth_scheme {
+    delinea_secret_server {
+      secret_id      = 42
+      username_field = "username"
+      password_field = "password"
+    }
+  }
+}
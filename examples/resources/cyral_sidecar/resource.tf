@@ -4,4 +4,10 @@ resource "cyral_sidecar" "some_resource_name" {
     labels = ["label1", "label2"]
     user_endpoint = ""
     bypass_mode = "failover"
+    services {
+        name = "pg-wire"
+        settings = {
+            maxConnections = "100"
+        }
+    }
 }
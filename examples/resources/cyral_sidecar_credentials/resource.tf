@@ -12,3 +12,21 @@ terraform {
     dynamodb_table = "some-dynamodb-table"
   }
 }
+
+resource "cyral_sidecar" "some_resource_name" {
+  name = "my-sidecar"
+  deployment_method = "terraform"
+}
+
+# Credentials rotated every 30 days, or whenever the `generation`
+# keeper changes. The replaced credentials remain valid for one
+# hour after each rotation, so the sidecar instances can pick up
+# the new secret without downtime.
+resource "cyral_sidecar_credentials" "some_resource_name" {
+  sidecar_id = cyral_sidecar.some_resource_name.id
+  rotate_after = "720h"
+  overlap = "1h"
+  keepers = {
+    generation = "1"
+  }
+}
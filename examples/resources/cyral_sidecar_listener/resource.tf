@@ -65,3 +65,55 @@ resource "cyral_sidecar_listener" "listener_dynamodb" {
     proxy_mode = true
   }
 }
+
+# Listener with PostgreSQL Settings
+resource "cyral_sidecar_listener" "listener_postgresql" {
+  sidecar_id = cyral_sidecar.sidecar.id
+  repo_types = ["postgresql"]
+  network_address {
+    port = 5432
+  }
+  postgresql_settings {
+    tls_mode              = "require"
+    wire_protocol_version = "3.0"
+  }
+}
+
+# Listener with Oracle Settings
+resource "cyral_sidecar_listener" "listener_oracle" {
+  sidecar_id = cyral_sidecar.sidecar.id
+  repo_types = ["oracle"]
+  network_address {
+    port = 1521
+  }
+  oracle_settings {
+    service_name_mappings = {
+      "SALES" = "SALESPDB"
+    }
+    native_network_encryption = "REQUIRED"
+  }
+}
+
+# Listener with MongoDB Settings
+resource "cyral_sidecar_listener" "listener_mongodb_compressed" {
+  sidecar_id = cyral_sidecar.sidecar.id
+  repo_types = ["mongodb"]
+  network_address {
+    port = 27018
+  }
+  mongodb_settings {
+    wire_compression = ["zstd", "snappy"]
+  }
+}
+
+# Listener with Snowflake Settings
+resource "cyral_sidecar_listener" "listener_snowflake" {
+  sidecar_id = cyral_sidecar.sidecar.id
+  repo_types = ["snowflake"]
+  network_address {
+    port = 8443
+  }
+  snowflake_settings {
+    rewrite_account_host = true
+  }
+}